@@ -1,16 +1,26 @@
 package day1
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
 // Counter defines a strategy for counting during dial rotations
 type Counter interface {
 	// Count processes a rotation and returns the count contribution
-	Count(rotation Rotation, position int) int
+	Count(rotation Rotation, position Position) int
 }
 
 // EndPositionCounter counts only when the dial ends at position 0
 type EndPositionCounter struct{}
 
-func (EndPositionCounter) Count(rotation Rotation, position int) int {
-	if applyRotation(rotation, position) == 0 {
+func (c EndPositionCounter) Count(rotation Rotation, position Position) int {
+	return c.CountOn(rotation, position, DialSize)
+}
+
+func (EndPositionCounter) CountOn(rotation Rotation, position Position, size int) int {
+	if rotation.ApplyOn(position, size) == 0 {
 		return 1
 	}
 	return 0
@@ -19,29 +29,100 @@ func (EndPositionCounter) Count(rotation Rotation, position int) int {
 // ZeroCrossingCounter counts every time the dial passes through 0
 type ZeroCrossingCounter struct{}
 
-func (ZeroCrossingCounter) Count(rotation Rotation, position int) int {
-	return countZeroCrossings(rotation, position)
+func (c ZeroCrossingCounter) Count(rotation Rotation, position Position) int {
+	return c.CountOn(rotation, position, DialSize)
+}
+
+func (ZeroCrossingCounter) CountOn(rotation Rotation, position Position, size int) int {
+	return countZeroCrossingsOn(rotation, position, size)
+}
+
+// SizedCounter is a Counter that also works on dials other than the standard
+// 100-click one. Dials built with NewDialWith use CountOn when the counter
+// provides it; plain Counters are always given standard-dial semantics.
+type SizedCounter interface {
+	Counter
+	CountOn(rotation Rotation, position Position, size int) int
+}
+
+// FloatCounter is a weighted counting strategy: each rotation contributes a
+// fractional amount instead of a whole count
+type FloatCounter interface {
+	// Count processes a rotation and returns its weighted contribution
+	Count(rotation Rotation, position Position) float64
+}
+
+// NearZeroCounter gives partial credit for landing close to 0: a landing d
+// clicks from 0 (either way around) earns (Radius+1-d)/(Radius+1), so an
+// exact hit is worth 1 and landings beyond Radius are worth nothing
+type NearZeroCounter struct {
+	Radius int
+}
+
+func (c NearZeroCounter) Count(rotation Rotation, position Position) float64 {
+	d := rotation.Apply(position).RingDistance(0)
+	if d > c.Radius {
+		return 0
+	}
+	return float64(c.Radius+1-d) / float64(c.Radius+1)
 }
 
 // Dial represents the safe's dial with a current position
 type Dial struct {
-	position int
-	counter  Counter
-	count    int
+	position  Position
+	size      int
+	counter   Counter
+	count     int
+	steps     int
+	observers []Observer
 }
 
 // NewDial creates a dial starting at position 50 with the given counter strategy
 func NewDial(counter Counter) *Dial {
+	return newDial(StartPosition, counter)
+}
+
+// NewDialWith creates a dial with size clicks starting at position, which is
+// wrapped onto the dial. The size must be positive; NewDialWith panics otherwise.
+func NewDialWith(position Position, size int, counter Counter) *Dial {
+	if size <= 0 {
+		panic(fmt.Sprintf("day1: dial size must be positive, got %d", size))
+	}
 	return &Dial{
-		position: 50,
+		position: position.NormalizeOn(size),
+		size:     size,
 		counter:  counter,
 	}
 }
 
+// newDial creates a standard dial starting at an arbitrary position
+func newDial(position Position, counter Counter) *Dial {
+	return NewDialWith(position, DialSize, counter)
+}
+
+// Observe registers observers notified after every subsequent rotation
+func (d *Dial) Observe(observers ...Observer) *Dial {
+	d.observers = append(d.observers, observers...)
+	return d
+}
+
 // Rotate applies a rotation, updates the count, and returns the dial for chaining
 func (d *Dial) Rotate(r Rotation) *Dial {
-	d.count += d.counter.Count(r, d.position)
-	d.position = applyRotation(r, d.position)
+	from := d.position
+	if sized, ok := d.counter.(SizedCounter); ok {
+		d.count += sized.CountOn(r, from, d.size)
+	} else {
+		d.count += d.counter.Count(r, from)
+	}
+	d.position = r.ApplyOn(from, d.size)
+
+	if len(d.observers) > 0 {
+		crossed := countZeroCrossingsOn(r, from, d.size)
+		for _, o := range d.observers {
+			o.OnRotation(d.steps, r, from, d.position, crossed)
+		}
+	}
+	d.steps++
 	return d
 }
 
@@ -50,37 +131,48 @@ func (d *Dial) Count() int {
 	return d.count
 }
 
-// applyRotation applies a rotation to a position and returns the new position (0-99)
-func applyRotation(r Rotation, position int) int {
-	var newPos int
-	if r.Direction == 'L' {
-		newPos = position - r.Distance
-	} else { // 'R'
-		newPos = position + r.Distance
+// Validate checks the dial's invariants: the position lies in [0, size)
+// and the count is non-negative. Every violation is reported in the error.
+func (d *Dial) Validate() error {
+	var errs []error
+	if d.position < 0 || int(d.position) >= d.size {
+		errs = append(errs, fmt.Errorf("position %d outside [0, %d)", d.position, d.size))
 	}
-
-	// Normalize to 0-99 range
-	newPos = newPos % 100
-	if newPos < 0 {
-		newPos += 100
+	if d.count < 0 {
+		errs = append(errs, fmt.Errorf("negative count %d", d.count))
 	}
-	return newPos
+	return errors.Join(errs...)
+}
+
+// Clone returns an independent copy of the dial sharing the same counter strategy.
+// The clone keeps the original's observers; observers added to it later are its own.
+func (d *Dial) Clone() *Dial {
+	clone := *d
+	clone.observers = slices.Clip(d.observers)
+	return &clone
 }
 
 // countZeroCrossings counts how many times a rotation crosses position 0
-func countZeroCrossings(r Rotation, from int) int {
-	if r.Direction == 'L' {
-		if from == 0 {
+func countZeroCrossings(r Rotation, from Position) int {
+	return countZeroCrossingsOn(r, from, DialSize)
+}
+
+// countZeroCrossingsOn counts how many times a rotation crosses position 0 on
+// a dial with size clicks; from must already be normalized onto that dial
+func countZeroCrossingsOn(r Rotation, from Position, size int) int {
+	pos := int(from)
+	if r.Direction == Left {
+		if pos == 0 {
 			// Starting at 0, count complete wraps
-			return r.Distance / 100
+			return r.Distance / size
 		}
 		// Going left from position p, we hit 0 after p steps
-		if r.Distance >= from {
-			return 1 + (r.Distance-from)/100
+		if r.Distance >= pos {
+			return 1 + (r.Distance-pos)/size
 		}
 		return 0
-	} else { // 'R'
-		// Going right, we cross 0 every 100 steps starting from (100 - position)
-		return (from + r.Distance) / 100
+	} else { // Right
+		// Going right, we cross 0 every size steps starting from (size - position)
+		return (pos + r.Distance) / size
 	}
 }
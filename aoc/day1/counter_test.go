@@ -0,0 +1,158 @@
+package day1
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// setState forces the dial into an arbitrary state, bypassing Rotate
+func (d *Dial) setState(position Position, count int) {
+	d.position = position
+	d.count = count
+}
+
+func TestDialCloneIsIndependent(t *testing.T) {
+	dial := NewDial(ZeroCrossingCounter{}).
+		Rotate(Rotation{Direction: Left, Distance: 68}).
+		Rotate(Rotation{Direction: Left, Distance: 30})
+
+	clone := dial.Clone()
+	if clone.position != dial.position || clone.count != dial.count {
+		t.Fatalf("clone state (%d, %d) differs from source (%d, %d)",
+			clone.position, clone.count, dial.position, dial.count)
+	}
+
+	clone.Rotate(Rotation{Direction: Right, Distance: 250})
+	dial.Rotate(Rotation{Direction: Right, Distance: 48})
+
+	if dial.position != 0 || dial.Count() != 2 {
+		t.Errorf("source dial: expected position 0 and count 2, got %d and %d", dial.position, dial.Count())
+	}
+	if clone.position != 2 || clone.Count() != 4 {
+		t.Errorf("cloned dial: expected position 2 and count 4, got %d and %d", clone.position, clone.Count())
+	}
+}
+
+func TestNearZeroCounterFractionalTotal(t *testing.T) {
+	// Landings: 98 (2 away), 0 (exact), 3 (3 away), 50 (out of radius)
+	pipeline := NewPipeline([]Rotation{{Right, 48}, {Right, 2}, {Right, 3}, {Left, 53}})
+	counter := NearZeroCounter{Radius: 3}
+
+	expected := 2.0/4 + 4.0/4 + 1.0/4 + 0
+	if got := pipeline.ReduceFloat(StartPosition, counter); got != expected {
+		t.Errorf("expected weighted total %v, got %v", expected, got)
+	}
+}
+
+func TestNearZeroCounterRadiusZeroMatchesEndPosition(t *testing.T) {
+	pipeline := NewPipeline([]Rotation{{Left, 68}, {Left, 30}, {Right, 48}, {Left, 5}, {Right, 60}, {Left, 55}})
+
+	want := float64(pipeline.Reduce(StartPosition, EndPositionCounter{}))
+	if got := pipeline.ReduceFloat(StartPosition, NearZeroCounter{}); got != want {
+		t.Errorf("expected radius 0 to count exact landings (%v), got %v", want, got)
+	}
+}
+
+func TestDialValidate(t *testing.T) {
+	dial := NewDial(ZeroCrossingCounter{})
+	for _, r := range []Rotation{{Left, 68}, {Left, 30}, {Right, 348}, {Left, 250}} {
+		if err := dial.Rotate(r).Validate(); err != nil {
+			t.Fatalf("after %v: unexpected invariant violation: %v", r, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		position Position
+		count    int
+		want     []string
+	}{
+		{DialSize, 0, []string{"position 100 outside [0, 100)"}},
+		{-1, 0, []string{"position -1 outside [0, 100)"}},
+		{10, -2, []string{"negative count -2"}},
+		{250, -1, []string{"position 250", "negative count -1"}},
+	} {
+		dial.setState(tc.position, tc.count)
+		err := dial.Validate()
+		if err == nil {
+			t.Errorf("state (%d, %d): expected a violation", tc.position, tc.count)
+			continue
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("state (%d, %d): error %q should mention %q", tc.position, tc.count, err, want)
+			}
+		}
+	}
+}
+
+// clickByClick simulates a rotation one click at a time on a dial with size
+// clicks, returning where it stops and how many clicks landed on 0
+func clickByClick(r Rotation, from Position, size int) (Position, int) {
+	step := Position(1)
+	if r.Direction == Left {
+		step = Position(size - 1) // one click left, kept non-negative
+	}
+
+	crossed := 0
+	for range r.Distance {
+		from = (from + step) % Position(size)
+		if from == 0 {
+			crossed++
+		}
+	}
+	return from, crossed
+}
+
+func TestSizedDialMatchesClickSimulation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1007))
+	for _, size := range []int{1, 2, 7, 10, 100, 360} {
+		for range 50 {
+			start := Position(rng.Intn(size))
+			crossings := NewDialWith(start, size, ZeroCrossingCounter{})
+			endings := NewDialWith(start, size, EndPositionCounter{})
+
+			position, wantCrossings, wantEndings := start, 0, 0
+			for range 20 {
+				r := Rotation{Direction: Right, Distance: rng.Intn(3 * size)}
+				if rng.Intn(2) == 0 {
+					r.Direction = Left
+				}
+
+				var crossed int
+				position, crossed = clickByClick(r, position, size)
+				wantCrossings += crossed
+				if position == 0 {
+					wantEndings++
+				}
+
+				crossings.Rotate(r)
+				endings.Rotate(r)
+				if crossings.position != position {
+					t.Fatalf("size %d: after %v expected position %d, got %d", size, r, position, crossings.position)
+				}
+				if err := crossings.Validate(); err != nil {
+					t.Fatalf("size %d: %v", size, err)
+				}
+			}
+
+			if crossings.Count() != wantCrossings {
+				t.Errorf("size %d from %d: expected %d crossings, got %d", size, start, wantCrossings, crossings.Count())
+			}
+			if endings.Count() != wantEndings {
+				t.Errorf("size %d from %d: expected %d zero endings, got %d", size, start, wantEndings, endings.Count())
+			}
+		}
+	}
+}
+
+func TestNewDialWithWrapsStart(t *testing.T) {
+	dial := NewDialWith(-3, 10, ZeroCrossingCounter{})
+	if dial.position != 7 {
+		t.Errorf("expected -3 to wrap to 7 on a 10-click dial, got %d", dial.position)
+	}
+	// R3 from 7 reaches 0 once; R10 then wraps past it again
+	if got := dial.Rotate(Rotation{Right, 3}).Rotate(Rotation{Right, 10}).Count(); got != 2 {
+		t.Errorf("expected 2 crossings, got %d", got)
+	}
+}
@@ -0,0 +1,14 @@
+package day1
+
+// Observer receives a callback for every rotation a dial performs.
+// Observers may keep state, so several independent aggregators can watch one run.
+type Observer interface {
+	// OnRotation is called after the rotation at index moves the dial from one
+	// position to another, passing through 0 crossed times
+	OnRotation(index int, r Rotation, from, to Position, crossed int)
+}
+
+// NopObserver ignores every event; use it as a default where an Observer is required
+type NopObserver struct{}
+
+func (NopObserver) OnRotation(int, Rotation, Position, Position, int) {}
@@ -0,0 +1,54 @@
+package day1
+
+import "testing"
+
+// crossingTally sums the crossings reported by each event
+type crossingTally struct {
+	total, events int
+	last          Position
+}
+
+func (c *crossingTally) OnRotation(index int, r Rotation, from, to Position, crossed int) {
+	c.total += crossed
+	c.events++
+	c.last = to
+}
+
+// indexRecorder checks that events arrive with consecutive indices and chained positions
+type indexRecorder struct {
+	t    *testing.T
+	next int
+	at   Position
+}
+
+func (o *indexRecorder) OnRotation(index int, r Rotation, from, to Position, crossed int) {
+	if index != o.next {
+		o.t.Errorf("expected index %d, got %d", o.next, index)
+	}
+	if from != o.at {
+		o.t.Errorf("step %d: expected from %v, got %v", index, o.at, from)
+	}
+	if to != r.Apply(from) {
+		o.t.Errorf("step %d: expected to %v, got %v", index, r.Apply(from), to)
+	}
+	o.next++
+	o.at = to
+}
+
+func TestObserverTalliesCrossings(t *testing.T) {
+	pipeline := parseProgram(t, "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\nR350")
+
+	tally := &crossingTally{}
+	recorder := &indexRecorder{t: t, at: StartPosition}
+	ends := pipeline.Reduce(StartPosition, EndPositionCounter{}, tally, recorder, NopObserver{})
+
+	if want := pipeline.CountCrossings(StartPosition); tally.total != want {
+		t.Errorf("expected observer tally %d to match CountCrossings, got %d", want, tally.total)
+	}
+	if tally.events != len(pipeline.Rotations()) {
+		t.Errorf("expected %d events, got %d", len(pipeline.Rotations()), tally.events)
+	}
+	if ends != 3 {
+		t.Errorf("observers should not change the counter result: expected 3, got %d", ends)
+	}
+}
@@ -0,0 +1,74 @@
+package day1
+
+// CrossingOracle answers "how many times does this program cross 0 from start?"
+// for every start position after a single pass over the program.
+//
+// The resting position before rotation i is start shifted by the net
+// displacement of rotations 0..i-1, so each rotation's contribution depends on
+// start only through that fixed offset. Writing the distance as
+// DialSize*q + m, a rotation always crosses 0 q times, plus once more when the
+// last m clicks reach 0: from positions [DialSize-m, DialSize) going right, or
+// [1, m] going left. That extra crossing covers a contiguous (cyclic) band of
+// starts, so Build marks every band in a difference array and one prefix sum
+// yields the answer for all DialSize starts.
+type CrossingOracle struct {
+	rotations []Rotation
+	crossings [DialSize]int
+	built     bool
+}
+
+// NewCrossingOracle creates an oracle for the pipeline's program. Call Build
+// before querying, or let the first Crossings call build it.
+func NewCrossingOracle(p *Pipeline) *CrossingOracle {
+	return &CrossingOracle{rotations: p.rotations}
+}
+
+// Build precomputes the crossing count for every start in O(len(program) + DialSize)
+func (o *CrossingOracle) Build() *CrossingOracle {
+	var diff [DialSize + 1]int
+	base := 0
+	offset := Position(0)
+
+	for _, r := range o.rotations {
+		base += r.Distance / DialSize
+		if m := r.Distance % DialSize; m > 0 {
+			// Positions p (before this rotation) that pick up the extra crossing
+			lo := Position(1)
+			if r.Direction == Right {
+				lo = Position(DialSize - m)
+			}
+			// ...and the starts that put the dial there: start = p - offset
+			addBand(&diff, lo.Sub(int(offset)), m)
+		}
+		offset = r.Apply(offset)
+	}
+
+	running := base
+	for start := range DialSize {
+		running += diff[start]
+		o.crossings[start] = running
+	}
+	o.built = true
+	return o
+}
+
+// addBand adds 1 to the length cells starting at from, wrapping past the end of the dial
+func addBand(diff *[DialSize + 1]int, from Position, length int) {
+	end := int(from) + length
+	diff[from]++
+	if end <= DialSize {
+		diff[end]--
+		return
+	}
+	diff[DialSize]--
+	diff[0]++
+	diff[end-DialSize]--
+}
+
+// Crossings returns how many times the program passes through 0 when run from start
+func (o *CrossingOracle) Crossings(start Position) int {
+	if !o.built {
+		o.Build()
+	}
+	return o.crossings[start.Normalize()]
+}
@@ -0,0 +1,32 @@
+package day1
+
+import "testing"
+
+func TestCrossingOracleMatchesNaive(t *testing.T) {
+	programs := []string{
+		"L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82",
+		"R350\nL250\nR100\nL100",
+		"R1\nL1\nR99\nL99\nR0",
+		"",
+	}
+
+	for _, program := range programs {
+		pipeline := parseProgram(t, program)
+		oracle := NewCrossingOracle(pipeline).Build()
+
+		for start := range Position(DialSize) {
+			if got, want := oracle.Crossings(start), pipeline.CountCrossings(start); got != want {
+				t.Errorf("program %q from %d: oracle says %d, naive says %d", program, start, got, want)
+			}
+		}
+	}
+}
+
+func TestCrossingOracleBuildsLazily(t *testing.T) {
+	pipeline := parseProgram(t, "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82")
+
+	oracle := NewCrossingOracle(pipeline)
+	if got := oracle.Crossings(StartPosition + DialSize); got != 6 {
+		t.Errorf("expected 6 crossings from 50 (given as 150), got %d", got)
+	}
+}
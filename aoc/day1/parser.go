@@ -2,19 +2,15 @@ package day1
 
 import (
 	"bufio"
+	"encoding/csv"
 	"fmt"
 	"io"
 	"os"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
-// Rotation represents a dial rotation instruction (L10, R25, etc.)
-type Rotation struct {
-	Direction rune // 'L' or 'R'
-	Distance  int
-}
-
 // RotationParser reads and parses dial rotation instructions from input
 type RotationParser struct {
 	scanner *bufio.Scanner
@@ -37,7 +33,7 @@ func (p *RotationParser) Parse(fn func(Rotation) error) error {
 			continue
 		}
 
-		rotation, err := parseRotation(line)
+		rotation, err := ParseRotation(line)
 		if err != nil {
 			return fmt.Errorf("line %d: %w", lineNum, err)
 		}
@@ -75,22 +71,144 @@ func ProcessFile(path string, fn func(Rotation) error) error {
 	return parser.Parse(fn)
 }
 
-// parseRotation parses a rotation string like "L68" or "R48"
-func parseRotation(s string) (Rotation, error) {
+// ParseCSV reads rotations from CSV with direction,distance columns, e.g.
+//
+//	direction,distance
+//	L,68
+//	CW,48
+//
+// A first row whose distance is not numeric is treated as a header and skipped.
+// Directions accept the same aliases as ParseRotation.
+func ParseCSV(r io.Reader) ([]Rotation, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var rotations []Rotation
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rotations, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+
+		dirField, distField := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		distance, err := strconv.Atoi(distField)
+		if err != nil {
+			if row == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("row %d: invalid distance %q: %w", row, distField, err)
+		}
+
+		direction, err := parseDirection(dirField)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		rotations = append(rotations, Rotation{Direction: direction, Distance: distance})
+	}
+}
+
+// parseDirection parses a standalone direction token such as "L", "CW" or "+"
+func parseDirection(s string) (Direction, error) {
+	for _, alias := range directionAliases {
+		if s == alias.prefix {
+			return alias.direction, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid direction: %q", s)
+}
+
+// ProcessFileWithProgressBytes is ProcessFile with progress reporting for large inputs.
+// onProgress receives the bytes consumed so far and the file size; the final
+// call reports readBytes == totalBytes once the whole file has been parsed.
+func ProcessFileWithProgressBytes(path string, fn func(Rotation) error, onProgress func(readBytes, totalBytes int64)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat file: %w", err)
+	}
+
+	counter := &countingReader{r: f, total: info.Size(), onProgress: onProgress}
+	return NewRotationParser(counter).Parse(fn)
+}
+
+// countingReader reports the running byte count after every read
+type countingReader struct {
+	r          io.Reader
+	read       int64
+	total      int64
+	onProgress func(readBytes, totalBytes int64)
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.read += int64(n)
+		c.onProgress(c.read, c.total)
+	}
+	return n, err
+}
+
+// ParseInline parses a program written on one line, e.g. "L68,R48,L7".
+// Rotations may be separated by commas, whitespace, or both.
+func ParseInline(program string) ([]Rotation, error) {
+	fields := strings.FieldsFunc(program, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	rotations := make([]Rotation, 0, len(fields))
+	for i, field := range fields {
+		rotation, err := ParseRotation(field)
+		if err != nil {
+			return nil, fmt.Errorf("rotation %d: %w", i+1, err)
+		}
+		rotations = append(rotations, rotation)
+	}
+	return rotations, nil
+}
+
+// directionAliases maps every accepted direction prefix to its canonical Direction
+var directionAliases = []struct {
+	prefix    string
+	direction Direction
+}{
+	{"CCW", Left},
+	{"CW", Right},
+	{"L", Left},
+	{"R", Right},
+	{"-", Left},
+	{"+", Right},
+}
+
+// ParseRotation parses a rotation string like "L68" or "R48".
+// The aliases "CCW"/"-" (Left) and "CW"/"+" (Right) are also accepted and
+// normalized, so "CW48" and "+48" both parse to R48.
+func ParseRotation(s string) (Rotation, error) {
 	s = strings.TrimSpace(s)
 	if len(s) < 2 {
 		return Rotation{}, fmt.Errorf("invalid rotation: too short")
 	}
 
-	dir := rune(s[0])
-	if dir != 'L' && dir != 'R' {
-		return Rotation{}, fmt.Errorf("invalid direction: %c", s[0])
-	}
+	for _, alias := range directionAliases {
+		rest, ok := strings.CutPrefix(s, alias.prefix)
+		if !ok {
+			continue
+		}
 
-	distance, err := strconv.Atoi(s[1:])
-	if err != nil {
-		return Rotation{}, fmt.Errorf("invalid distance in %q: %w", s, err)
+		distance, err := strconv.Atoi(rest)
+		if err != nil {
+			return Rotation{}, fmt.Errorf("invalid distance in %q: %w", s, err)
+		}
+		return Rotation{Direction: alias.direction, Distance: distance}, nil
 	}
 
-	return Rotation{Direction: dir, Distance: distance}, nil
+	return Rotation{}, fmt.Errorf("invalid direction: %c", s[0])
 }
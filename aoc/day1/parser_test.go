@@ -0,0 +1,142 @@
+package day1
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseRotationAliases(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Rotation
+	}{
+		{"L68", Rotation{Left, 68}},
+		{"R48", Rotation{Right, 48}},
+		{"+48", Rotation{Right, 48}},
+		{"CW48", Rotation{Right, 48}},
+		{"-68", Rotation{Left, 68}},
+		{"CCW68", Rotation{Left, 68}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRotation(tt.input)
+		if err != nil {
+			t.Errorf("ParseRotation(%q): unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRotation(%q): expected %v, got %v", tt.input, tt.want, got)
+		}
+	}
+}
+
+func TestRotationStringIsCanonical(t *testing.T) {
+	for input, want := range map[string]string{"CW48": "R48", "-68": "L68", "CCW5": "L5"} {
+		r, err := ParseRotation(input)
+		if err != nil {
+			t.Fatalf("ParseRotation(%q): %v", input, err)
+		}
+		if r.String() != want {
+			t.Errorf("ParseRotation(%q).String(): expected %q, got %q", input, want, r.String())
+		}
+	}
+}
+
+func TestParseRotationInvalid(t *testing.T) {
+	for _, input := range []string{"X10", "L", "CWx", "C10"} {
+		if _, err := ParseRotation(input); err == nil {
+			t.Errorf("ParseRotation(%q): expected an error", input)
+		}
+	}
+}
+
+func TestParseInline(t *testing.T) {
+	rotations, err := ParseInline("L68, R48 L7,,CW3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Rotation{{Left, 68}, {Right, 48}, {Left, 7}, {Right, 3}}
+	if len(rotations) != len(expected) {
+		t.Fatalf("expected %d rotations, got %v", len(expected), rotations)
+	}
+	for i := range expected {
+		if rotations[i] != expected[i] {
+			t.Errorf("rotation %d: expected %v, got %v", i, expected[i], rotations[i])
+		}
+	}
+}
+
+func TestProcessFileWithProgressBytes(t *testing.T) {
+	var sb strings.Builder
+	for i := range 5000 {
+		fmt.Fprintf(&sb, "L%d\nR%d\n", i%300, i%170)
+	}
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rotations := 0
+	var calls int
+	var lastRead, lastTotal int64
+	err := ProcessFileWithProgressBytes(path,
+		func(Rotation) error {
+			rotations++
+			return nil
+		},
+		func(read, total int64) {
+			if read < lastRead {
+				t.Errorf("progress went backwards: %d after %d", read, lastRead)
+			}
+			calls++
+			lastRead, lastTotal = read, total
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rotations != 10000 {
+		t.Errorf("expected 10000 rotations, got %d", rotations)
+	}
+	if calls < 2 {
+		t.Errorf("expected several progress callbacks, got %d", calls)
+	}
+	if lastTotal != int64(sb.Len()) || lastRead != lastTotal {
+		t.Errorf("expected final progress %d/%d (100%%), got %d/%d", sb.Len(), sb.Len(), lastRead, lastTotal)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	expected := []Rotation{{Left, 68}, {Right, 48}, {Left, 5}}
+
+	for name, input := range map[string]string{
+		"headered":   "direction,distance\nL,68\nCW,48\n-, 5\n",
+		"headerless": "L,68\nR,48\nCCW,5\n",
+	} {
+		rotations, err := ParseCSV(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if !slices.Equal(rotations, expected) {
+			t.Errorf("%s: expected %v, got %v", name, expected, rotations)
+		}
+	}
+}
+
+func TestParseCSVErrorsIncludeRow(t *testing.T) {
+	tests := map[string]string{
+		"direction,distance\nL,68\nX,4\n": "row 3",
+		"L,68\nR,abc\n":                   "row 2",
+		"L,68\nR\n":                       "row 2",
+	}
+	for input, want := range tests {
+		_, err := ParseCSV(strings.NewReader(input))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseCSV(%q): expected error mentioning %q, got %v", input, want, err)
+		}
+	}
+}
@@ -1,18 +1,25 @@
 package day1
 
-import "fmt"
+import (
+	"io"
+
+	"adv2025/aoc/input"
+)
 
 // Part1 solves part 1: count how many times the dial ends at position 0
 func Part1(inputPath string) (int, error) {
-	dial := NewDial(EndPositionCounter{})
+	return Part1At(inputPath, int(StartPosition))
+}
 
-	err := ProcessFile(inputPath, func(r Rotation) error {
-		dial.Rotate(r)
-		return nil
-	})
-	if err != nil {
-		return 0, fmt.Errorf("processing rotations: %w", err)
-	}
+// Part1FromReader is Part1 for rotations read from r
+func Part1FromReader(r io.Reader) (int, error) {
+	return countFrom(r, StartPosition, EndPositionCounter{})
+}
 
-	return dial.Count(), nil
+// Part1At solves part 1 with the dial starting at start instead of 50.
+// Starts outside the dial are wrapped, so 150 behaves like 50.
+func Part1At(inputPath string, start int) (int, error) {
+	return input.SolveFile(inputPath, func(r io.Reader) (int, error) {
+		return countFrom(r, Position(start).Normalize(), EndPositionCounter{})
+	})
 }
@@ -1,18 +1,25 @@
 package day1
 
-import "fmt"
+import (
+	"io"
+
+	"adv2025/aoc/input"
+)
 
 // Part2 solves part 2: count how many times the dial passes through position 0
 func Part2(inputPath string) (int, error) {
-	dial := NewDial(ZeroCrossingCounter{})
+	return Part2At(inputPath, int(StartPosition))
+}
 
-	err := ProcessFile(inputPath, func(r Rotation) error {
-		dial.Rotate(r)
-		return nil
-	})
-	if err != nil {
-		return 0, fmt.Errorf("processing rotations: %w", err)
-	}
+// Part2FromReader is Part2 for rotations read from r
+func Part2FromReader(r io.Reader) (int, error) {
+	return countFrom(r, StartPosition, ZeroCrossingCounter{})
+}
 
-	return dial.Count(), nil
+// Part2At solves part 2 with the dial starting at start instead of 50.
+// Starts outside the dial are wrapped, so 150 behaves like 50.
+func Part2At(inputPath string, start int) (int, error) {
+	return input.SolveFile(inputPath, func(r io.Reader) (int, error) {
+		return countFrom(r, Position(start).Normalize(), ZeroCrossingCounter{})
+	})
 }
@@ -0,0 +1,239 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Pipeline is an ordered sequence of rotations supporting functional transformations
+type Pipeline struct {
+	rotations []Rotation
+}
+
+// NewPipeline creates a pipeline over the given rotations
+func NewPipeline(rotations []Rotation) *Pipeline {
+	return &Pipeline{rotations: rotations}
+}
+
+// LoadPipeline reads every rotation from a file into a pipeline
+func LoadPipeline(path string) (*Pipeline, error) {
+	var rotations []Rotation
+	err := ProcessFile(path, func(r Rotation) error {
+		rotations = append(rotations, r)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading rotations: %w", err)
+	}
+	return NewPipeline(rotations), nil
+}
+
+// countFrom streams the rotations in r through a dial starting at start and
+// returns the counter's total
+func countFrom(r io.Reader, start Position, counter Counter) (int, error) {
+	dial := newDial(start, counter)
+
+	rotations := 0
+	err := NewRotationParser(r).Parse(func(rotation Rotation) error {
+		dial.Rotate(rotation)
+		rotations++
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("processing rotations: %w", err)
+	}
+
+	slog.Debug("day1: rotations processed", "rotations", rotations, "start", int(start), "count", dial.Count())
+	return dial.Count(), nil
+}
+
+// Rotations returns the rotations held by the pipeline
+func (p *Pipeline) Rotations() []Rotation {
+	return p.rotations
+}
+
+// Filter returns a new pipeline containing only rotations matching the predicate
+func (p *Pipeline) Filter(keep func(Rotation) bool) *Pipeline {
+	var filtered []Rotation
+	for _, r := range p.rotations {
+		if keep(r) {
+			filtered = append(filtered, r)
+		}
+	}
+	return NewPipeline(filtered)
+}
+
+// Map returns a new pipeline with every rotation transformed
+func (p *Pipeline) Map(transform func(Rotation) Rotation) *Pipeline {
+	mapped := make([]Rotation, len(p.rotations))
+	for i, r := range p.rotations {
+		mapped[i] = transform(r)
+	}
+	return NewPipeline(mapped)
+}
+
+// Reduce runs every rotation through a dial starting at start and returns the final count.
+// Any observers are notified of each step along the way.
+func (p *Pipeline) Reduce(start Position, counter Counter, observers ...Observer) int {
+	dial := newDial(start, counter).Observe(observers...)
+	for _, r := range p.rotations {
+		dial.Rotate(r)
+	}
+	return dial.Count()
+}
+
+// ReduceFloat is Reduce for weighted counters: it walks the program from start
+// and sums each rotation's fractional contribution
+func (p *Pipeline) ReduceFloat(start Position, counter FloatCounter) float64 {
+	total := 0.0
+	position := start.Normalize()
+	for _, r := range p.rotations {
+		total += counter.Count(r, position)
+		position = r.Apply(position)
+	}
+	return total
+}
+
+// CountCrossings returns how many times the program passes through 0 from start
+func (p *Pipeline) CountCrossings(start Position) int {
+	return p.Reduce(start, ZeroCrossingCounter{})
+}
+
+// MaxCrossingRotation finds the single rotation that passes through 0 the most
+// times when the program runs from start. Ties go to the earliest rotation; an
+// empty program returns index -1.
+func (p *Pipeline) MaxCrossingRotation(start Position) (index int, r Rotation, crossings int) {
+	index = -1
+	position := start.Normalize()
+	for i, rotation := range p.rotations {
+		if crossed := countZeroCrossings(rotation, position); index < 0 || crossed > crossings {
+			index, r, crossings = i, rotation, crossed
+		}
+		position = rotation.Apply(position)
+	}
+	return index, r, crossings
+}
+
+// Reverse returns the program that retraces this one: rotations in reverse
+// order, each inverted. Running p then p.Reverse() leaves the dial at its start.
+func (p *Pipeline) Reverse() *Pipeline {
+	reversed := make([]Rotation, len(p.rotations))
+	for i, r := range p.rotations {
+		reversed[len(p.rotations)-1-i] = r.Invert()
+	}
+	return NewPipeline(reversed)
+}
+
+// CostModel assigns a per-click cost to each rotation direction
+type CostModel struct {
+	LeftCost, RightCost float64
+}
+
+// Cost returns the cost of executing a single rotation under the model
+func (m CostModel) Cost(r Rotation) float64 {
+	if r.Direction == Left {
+		return float64(r.Distance) * m.LeftCost
+	}
+	return float64(r.Distance) * m.RightCost
+}
+
+// TotalCost returns the summed cost of executing every rotation in the pipeline
+func (p *Pipeline) TotalCost(model CostModel) float64 {
+	total := 0.0
+	for _, r := range p.rotations {
+		total += model.Cost(r)
+	}
+	return total
+}
+
+// Trajectory returns the resting position of the dial after each rotation
+func (p *Pipeline) Trajectory(start Position) []Position {
+	positions := make([]Position, len(p.rotations))
+	current := start.Normalize()
+	for i, r := range p.rotations {
+		current = r.Apply(current)
+		positions[i] = current
+	}
+	return positions
+}
+
+// PositionDeltas delta-encodes the trajectory: each entry is the signed minimal
+// click count from the previous resting position (start for the first entry),
+// taking the shorter way around the ring
+func (p *Pipeline) PositionDeltas(start Position) []int {
+	deltas := make([]int, len(p.rotations))
+	prev := start.Normalize()
+	for i, pos := range p.Trajectory(start) {
+		deltas[i] = shortestDelta(prev, pos)
+		prev = pos
+	}
+	return deltas
+}
+
+// ProgramFromPositions rebuilds the shortest program visiting the given resting positions in order.
+// It is the inverse of Trajectory: NewPipeline(ProgramFromPositions(s, ps)).Trajectory(s) equals ps.
+func ProgramFromPositions(start Position, positions []Position) []Rotation {
+	rotations := make([]Rotation, len(positions))
+	prev := start.Normalize()
+	for i, pos := range positions {
+		rotations[i] = ShortestRotation(prev, pos)
+		prev = pos.Normalize()
+	}
+	return rotations
+}
+
+// IsDegenerate reports whether the program never leaves start: every resting
+// position equals start (e.g. only L100/R100 rotations). An empty program is
+// trivially degenerate.
+func (p *Pipeline) IsDegenerate(start Position) bool {
+	start = start.Normalize()
+	for _, pos := range p.Trajectory(start) {
+		if pos != start {
+			return false
+		}
+	}
+	return true
+}
+
+// FirstUniqueTail finds the first resting position the dial visits exactly once:
+// after resting there at step index, it never returns. The bool is false when
+// every resting position is revisited.
+func (p *Pipeline) FirstUniqueTail(start Position) (Position, int, bool) {
+	trajectory := p.Trajectory(start)
+
+	last := make(map[Position]int, len(trajectory))
+	for i, pos := range trajectory {
+		last[pos] = i
+	}
+
+	seen := make(map[Position]bool, len(trajectory))
+	for i, pos := range trajectory {
+		if !seen[pos] && last[pos] == i {
+			return pos, i, true
+		}
+		seen[pos] = true
+	}
+	return 0, -1, false
+}
+
+// SplitAtZero breaks the program into segments that each end with the dial
+// resting on 0. A trailing segment that does not end on 0 is kept as the last
+// entry; no empty segment is produced when the program itself ends on 0.
+func (p *Pipeline) SplitAtZero(start Position) [][]Rotation {
+	var segments [][]Rotation
+	var current []Rotation
+
+	for i, pos := range p.Trajectory(start) {
+		current = append(current, p.rotations[i])
+		if pos.IsZero() {
+			segments = append(segments, current)
+			current = nil
+		}
+	}
+
+	if len(current) > 0 {
+		segments = append(segments, current)
+	}
+	return segments
+}
@@ -0,0 +1,239 @@
+package day1
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func parseProgram(t *testing.T, input string) *Pipeline {
+	t.Helper()
+
+	var rotations []Rotation
+	err := NewRotationParser(strings.NewReader(input)).Parse(func(r Rotation) error {
+		rotations = append(rotations, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	return NewPipeline(rotations)
+}
+
+func TestTotalCostAsymmetric(t *testing.T) {
+	pipeline := parseProgram(t, "L10\nR20\nL5\nR1")
+	model := CostModel{LeftCost: 2.5, RightCost: 0.5}
+
+	// Left: (10 + 5) * 2.5 = 37.5, Right: (20 + 1) * 0.5 = 10.5
+	expected := 48.0
+	if got := pipeline.TotalCost(model); got != expected {
+		t.Errorf("expected total cost %v, got %v", expected, got)
+	}
+}
+
+func TestTotalCostZeroDirection(t *testing.T) {
+	pipeline := parseProgram(t, "L10\nR20")
+	model := CostModel{LeftCost: 0, RightCost: 3}
+
+	expected := 60.0
+	if got := pipeline.TotalCost(model); got != expected {
+		t.Errorf("expected total cost %v, got %v", expected, got)
+	}
+}
+
+func TestPositionDeltasShortestDirection(t *testing.T) {
+	pipeline := parseProgram(t, "L68\nL30\nR48\nL5\nR160")
+
+	// Resting positions: 82, 52, 0, 95, 55
+	expected := []int{32, -30, 48, -5, -40}
+	deltas := pipeline.PositionDeltas(50)
+
+	if len(deltas) != len(expected) {
+		t.Fatalf("expected %d deltas, got %d", len(expected), len(deltas))
+	}
+	for i := range expected {
+		if deltas[i] != expected[i] {
+			t.Errorf("delta %d: expected %d, got %d", i, expected[i], deltas[i])
+		}
+	}
+}
+
+func TestProgramFromPositionsRoundTrip(t *testing.T) {
+	pipeline := parseProgram(t, "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\nR250")
+	start := Position(50)
+
+	positions := pipeline.Trajectory(start)
+	rebuilt := NewPipeline(ProgramFromPositions(start, positions))
+
+	replayed := rebuilt.Trajectory(start)
+	for i := range positions {
+		if replayed[i] != positions[i] {
+			t.Errorf("step %d: expected position %v, got %v", i, positions[i], replayed[i])
+		}
+	}
+
+	original := pipeline.PositionDeltas(start)
+	for i, delta := range rebuilt.PositionDeltas(start) {
+		if delta != original[i] {
+			t.Errorf("step %d: expected delta %d, got %d", i, original[i], delta)
+		}
+	}
+}
+
+func TestIsDegenerate(t *testing.T) {
+	if !parseProgram(t, "L100\nL100\nR200\nL0").IsDegenerate(50) {
+		t.Error("expected full-turn program to be degenerate")
+	}
+
+	// Net displacement is zero, but the dial rests elsewhere in between
+	if parseProgram(t, "L10\nR10").IsDegenerate(50) {
+		t.Error("expected program resting at 40 to be non-degenerate")
+	}
+}
+
+func TestReverseReturnsToStart(t *testing.T) {
+	pipeline := parseProgram(t, "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82")
+	start := Position(50)
+
+	forward := pipeline.Trajectory(start)
+	end := forward[len(forward)-1]
+
+	backward := pipeline.Reverse().Trajectory(end)
+	if got := backward[len(backward)-1]; got != start {
+		t.Errorf("expected reverse program to return to %v, got %v", start, got)
+	}
+
+	// Intermediate resting positions are retraced in reverse order
+	for i := 0; i < len(forward)-1; i++ {
+		if backward[i] != forward[len(forward)-2-i] {
+			t.Errorf("reverse step %d: expected %v, got %v", i, forward[len(forward)-2-i], backward[i])
+		}
+	}
+}
+
+func TestReverseCrossingSymmetry(t *testing.T) {
+	// Neither the start (50) nor the end (32) is 0, so both directions see the same zero clicks
+	pipeline := parseProgram(t, "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82")
+	start := Position(50)
+
+	forward := pipeline.Trajectory(start)
+	end := forward[len(forward)-1]
+
+	want := pipeline.CountCrossings(start)
+	if got := pipeline.Reverse().CountCrossings(end); got != want {
+		t.Errorf("expected reverse crossings %d to match forward, got %d", want, got)
+	}
+}
+
+func TestFirstUniqueTail(t *testing.T) {
+	// Resting positions: 60, 50, 60, 70, 50, 90
+	pipeline := parseProgram(t, "R10\nL10\nR10\nR10\nL20\nR40")
+
+	pos, index, found := pipeline.FirstUniqueTail(50)
+	if !found {
+		t.Fatal("expected a unique tail position")
+	}
+	if pos != 70 || index != 3 {
+		t.Errorf("expected position 70 at step 3, got %v at step %d", pos, index)
+	}
+}
+
+func TestFirstUniqueTailNone(t *testing.T) {
+	// Resting positions: 60, 50, 60, 50
+	pipeline := parseProgram(t, "R10\nL10\nR10\nL10")
+
+	if _, _, found := pipeline.FirstUniqueTail(50); found {
+		t.Error("expected no unique tail when every position repeats")
+	}
+}
+
+func TestSplitAtZero(t *testing.T) {
+	// Rests on 0 after R50 and again after L10,L90; R5,L3 is the tail
+	pipeline := parseProgram(t, "R50\nL10\nL90\nR5\nL3")
+
+	segments := pipeline.SplitAtZero(50)
+	expected := [][]Rotation{
+		{{Right, 50}},
+		{{Left, 10}, {Left, 90}},
+		{{Right, 5}, {Left, 3}},
+	}
+
+	if len(segments) != len(expected) {
+		t.Fatalf("expected %d segments, got %d: %v", len(expected), len(segments), segments)
+	}
+	for i := range expected {
+		if !slices.Equal(segments[i], expected[i]) {
+			t.Errorf("segment %d: expected %v, got %v", i, expected[i], segments[i])
+		}
+	}
+}
+
+func TestSplitAtZeroEndingOnZero(t *testing.T) {
+	segments := parseProgram(t, "R25\nR25").SplitAtZero(50)
+
+	if len(segments) != 1 || len(segments[0]) != 2 {
+		t.Errorf("expected a single two-rotation segment, got %v", segments)
+	}
+}
+
+func TestPartsAtStart(t *testing.T) {
+	const program = "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\n"
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(program), 0o644); err != nil {
+		t.Fatalf("failed to write input: %v", err)
+	}
+	pipeline := parseProgram(t, program)
+
+	for start := range DialSize {
+		part1, err := Part1At(path, start)
+		if err != nil {
+			t.Fatalf("Part1At(%d): unexpected error: %v", start, err)
+		}
+		if want := pipeline.Reduce(Position(start), EndPositionCounter{}); part1 != want {
+			t.Errorf("Part1At(%d) = %d, want %d", start, part1, want)
+		}
+
+		part2, err := Part2At(path, start)
+		if err != nil {
+			t.Fatalf("Part2At(%d): unexpected error: %v", start, err)
+		}
+		if want := pipeline.CountCrossings(Position(start)); part2 != want {
+			t.Errorf("Part2At(%d) = %d, want %d", start, part2, want)
+		}
+	}
+
+	// The default start reproduces the puzzle answers, and starts wrap
+	for _, start := range []int{int(StartPosition), 150, -50} {
+		if got, _ := Part1At(path, start); got != 3 {
+			t.Errorf("Part1At(%d) = %d, want 3", start, got)
+		}
+		if got, _ := Part2At(path, start); got != 6 {
+			t.Errorf("Part2At(%d) = %d, want 6", start, got)
+		}
+	}
+}
+
+func TestMaxCrossingRotation(t *testing.T) {
+	// From 50: L68 crosses once, R48 lands on 0 (once), L350 from 0 wraps 3 times
+	pipeline := parseProgram(t, "L68\nL30\nR48\nL350\nR60")
+
+	index, r, crossings := pipeline.MaxCrossingRotation(StartPosition)
+	if index != 3 || r != (Rotation{Left, 350}) || crossings != 3 {
+		t.Errorf("expected L350 at index 3 with 3 crossings, got %v at %d with %d", r, index, crossings)
+	}
+}
+
+func TestMaxCrossingRotationTies(t *testing.T) {
+	pipeline := parseProgram(t, "L68\nR60\nL1")
+
+	index, r, crossings := pipeline.MaxCrossingRotation(StartPosition)
+	if index != 0 || r != (Rotation{Left, 68}) || crossings != 1 {
+		t.Errorf("expected the earliest tie L68 at index 0, got %v at %d with %d", r, index, crossings)
+	}
+
+	if index, _, crossings := NewPipeline(nil).MaxCrossingRotation(StartPosition); index != -1 || crossings != 0 {
+		t.Errorf("empty program: expected index -1 and 0 crossings, got %d and %d", index, crossings)
+	}
+}
@@ -0,0 +1,120 @@
+package day1
+
+import "fmt"
+
+// DialSize is the number of clicks on the safe's dial (positions 0-99)
+const DialSize = 100
+
+// StartPosition is where the dial points before the first rotation
+const StartPosition Position = 50
+
+// Position is a resting point on the dial, always in the range [0, DialSize) once normalized
+type Position int
+
+// Normalize wraps the position onto the dial
+func (p Position) Normalize() Position {
+	return p.NormalizeOn(DialSize)
+}
+
+// NormalizeOn wraps the position onto a dial with size clicks (positions 0 to size-1)
+func (p Position) NormalizeOn(size int) Position {
+	n := p % Position(size)
+	if n < 0 {
+		n += Position(size)
+	}
+	return n
+}
+
+// IsZero reports whether the dial points at 0
+func (p Position) IsZero() bool {
+	return p.Normalize() == 0
+}
+
+// Add moves n clicks toward higher numbers, wrapping around the dial
+func (p Position) Add(n int) Position {
+	return (p + Position(n)).Normalize()
+}
+
+// Sub moves n clicks toward lower numbers, wrapping around the dial
+func (p Position) Sub(n int) Position {
+	return (p - Position(n)).Normalize()
+}
+
+// RingDistance returns the fewest clicks between two positions going either way around
+func (p Position) RingDistance(other Position) int {
+	return abs(shortestDelta(p, other))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d", int(p))
+}
+
+// Direction is the way the dial turns
+type Direction rune
+
+const (
+	Left  Direction = 'L' // toward lower numbers (counterclockwise)
+	Right Direction = 'R' // toward higher numbers (clockwise)
+)
+
+func (d Direction) String() string {
+	return string(d)
+}
+
+// Rotation represents a dial rotation instruction (L10, R25, etc.)
+type Rotation struct {
+	Direction Direction
+	Distance  int
+}
+
+// String formats the rotation in canonical form, e.g. "L68"
+func (r Rotation) String() string {
+	return fmt.Sprintf("%c%d", r.Direction, r.Distance)
+}
+
+// Apply rotates from a position and returns the new, normalized position
+func (r Rotation) Apply(p Position) Position {
+	return r.ApplyOn(p, DialSize)
+}
+
+// ApplyOn is Apply for a dial with size clicks
+func (r Rotation) ApplyOn(p Position, size int) Position {
+	if r.Direction == Left {
+		return (p - Position(r.Distance)).NormalizeOn(size)
+	}
+	return (p + Position(r.Distance)).NormalizeOn(size)
+}
+
+// Invert returns the rotation that undoes r
+func (r Rotation) Invert() Rotation {
+	if r.Direction == Left {
+		return Rotation{Direction: Right, Distance: r.Distance}
+	}
+	return Rotation{Direction: Left, Distance: r.Distance}
+}
+
+// ShortestRotation returns the rotation covering the fewest clicks from one position to another.
+// Ties (exactly half a turn) resolve to a right rotation.
+func ShortestRotation(from, to Position) Rotation {
+	delta := shortestDelta(from, to)
+	if delta < 0 {
+		return Rotation{Direction: Left, Distance: -delta}
+	}
+	return Rotation{Direction: Right, Distance: delta}
+}
+
+// shortestDelta returns the signed click count in (-DialSize/2, DialSize/2] from one position to another
+func shortestDelta(from, to Position) int {
+	delta := int(to.Sub(int(from)))
+	if delta > DialSize/2 {
+		delta -= DialSize
+	}
+	return delta
+}
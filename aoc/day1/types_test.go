@@ -0,0 +1,58 @@
+package day1
+
+import "testing"
+
+func TestPositionAddSub(t *testing.T) {
+	tests := []struct {
+		p        Position
+		n        int
+		add, sub Position
+	}{
+		{50, 10, 60, 40},
+		{95, 10, 5, 85},
+		{5, 10, 15, 95},
+		{0, 100, 0, 0},
+		{0, 250, 50, 50},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Add(tt.n); got != tt.add {
+			t.Errorf("%v.Add(%d): expected %v, got %v", tt.p, tt.n, tt.add, got)
+		}
+		if got := tt.p.Sub(tt.n); got != tt.sub {
+			t.Errorf("%v.Sub(%d): expected %v, got %v", tt.p, tt.n, tt.sub, got)
+		}
+	}
+}
+
+func TestPositionRingDistance(t *testing.T) {
+	tests := []struct {
+		a, b Position
+		want int
+	}{
+		{95, 5, 10},
+		{5, 95, 10},
+		{0, 50, 50},
+		{50, 50, 0},
+		{99, 0, 1},
+		{10, 70, 40},
+	}
+	for _, tt := range tests {
+		if got := tt.a.RingDistance(tt.b); got != tt.want {
+			t.Errorf("RingDistance(%v, %v): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestShortestRotationUsesRingDistance(t *testing.T) {
+	for from := Position(0); from < DialSize; from += 7 {
+		for to := Position(0); to < DialSize; to += 11 {
+			r := ShortestRotation(from, to)
+			if r.Apply(from) != to {
+				t.Fatalf("ShortestRotation(%v, %v) = %v does not reach the target", from, to, r)
+			}
+			if r.Distance != from.RingDistance(to) {
+				t.Fatalf("ShortestRotation(%v, %v) = %v is not minimal", from, to, r)
+			}
+		}
+	}
+}
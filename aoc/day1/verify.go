@@ -0,0 +1,63 @@
+package day1
+
+import "fmt"
+
+// formulas are the closed-form rules VerifyProgram checks against a
+// click-by-click simulation
+type formulas struct {
+	apply     func(r Rotation, from Position) Position
+	crossings func(r Rotation, from Position) int
+}
+
+// closedForm is what the solvers actually use
+var closedForm = formulas{
+	apply:     Rotation.Apply,
+	crossings: countZeroCrossings,
+}
+
+// VerifyProgram counts end-at-zero landings and zero crossings twice: once by
+// turning the dial a single click at a time, and once with the closed-form
+// Apply and countZeroCrossings the solvers rely on. It returns an error naming
+// the first rotation where the two disagree.
+func VerifyProgram(start Position, rotations []Rotation) error {
+	return verifyProgram(start, rotations, closedForm)
+}
+
+func verifyProgram(start Position, rotations []Rotation, f formulas) error {
+	clicked := start.Normalize()
+	computed := clicked
+	clickedEnds, computedEnds := 0, 0
+	clickedCrossings, computedCrossings := 0, 0
+
+	for i, r := range rotations {
+		step := 1
+		if r.Direction == Left {
+			step = -1
+		}
+		for range r.Distance {
+			clicked = clicked.Add(step)
+			if clicked.IsZero() {
+				clickedCrossings++
+			}
+		}
+		if clicked.IsZero() {
+			clickedEnds++
+		}
+
+		computedCrossings += f.crossings(r, computed)
+		computed = f.apply(r, computed)
+		if computed.IsZero() {
+			computedEnds++
+		}
+
+		switch {
+		case clicked != computed:
+			return fmt.Errorf("rotation %d (%v): simulation ends at %v, formula at %v", i+1, r, clicked, computed)
+		case clickedEnds != computedEnds:
+			return fmt.Errorf("rotation %d (%v): simulation counts %d zero endings, formula %d", i+1, r, clickedEnds, computedEnds)
+		case clickedCrossings != computedCrossings:
+			return fmt.Errorf("rotation %d (%v): simulation counts %d crossings, formula %d", i+1, r, clickedCrossings, computedCrossings)
+		}
+	}
+	return nil
+}
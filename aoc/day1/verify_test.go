@@ -0,0 +1,45 @@
+package day1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerifyProgram(t *testing.T) {
+	programs := []string{
+		"L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82",
+		"R50\nL200\nR1000\nL0\nR350\nL51",
+	}
+	for _, program := range programs {
+		rotations := parseProgram(t, program).Rotations()
+		for _, start := range []Position{0, 1, StartPosition, 99} {
+			if err := VerifyProgram(start, rotations); err != nil {
+				t.Errorf("program %q from %v: unexpected mismatch: %v", program, start, err)
+			}
+		}
+	}
+}
+
+func TestVerifyProgramCatchesBrokenFormula(t *testing.T) {
+	// A classic off-by-one: treating a left turn from 0 as crossing immediately
+	broken := closedForm
+	broken.crossings = func(r Rotation, from Position) int {
+		if r.Direction == Left && from == 0 {
+			return 1 + r.Distance/DialSize
+		}
+		return countZeroCrossings(r, from)
+	}
+
+	rotations := parseProgram(t, "R50\nL5\nR5").Rotations()
+	err := verifyProgram(StartPosition, rotations, broken)
+	if err == nil {
+		t.Fatal("expected the broken crossing formula to be caught")
+	}
+	if !strings.Contains(err.Error(), "rotation 2 (L5)") || !strings.Contains(err.Error(), "crossings") {
+		t.Errorf("error should point at the L5 crossing count, got %v", err)
+	}
+
+	if err := verifyProgram(StartPosition, rotations, closedForm); err != nil {
+		t.Errorf("unexpected mismatch with the real formulas: %v", err)
+	}
+}
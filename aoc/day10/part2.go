@@ -1,10 +1,20 @@
 package day10
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"adv2025/aoc/input"
+)
 
 // Part2 solves Day 10 Part 2
 func Part2(inputPath string) (int, error) {
-	lines, err := FromFile(inputPath)
+	return input.SolveFile(inputPath, Part2FromReader)
+}
+
+// Part2FromReader is Part2 for input read from r
+func Part2FromReader(r io.Reader) (int, error) {
+	lines, err := NewParser(r).ParseAll()
 	if err != nil {
 		return 0, fmt.Errorf("loading input: %w", err)
 	}
@@ -1,10 +1,20 @@
 package day12
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"adv2025/aoc/input"
+)
 
 // Part1 solves Day 12 Part 1
 func Part1(inputPath string) (int, error) {
-	lines, err := FromFile(inputPath)
+	return input.SolveFile(inputPath, Part1FromReader)
+}
+
+// Part1FromReader is Part1 for input read from r
+func Part1FromReader(r io.Reader) (int, error) {
+	lines, err := NewParser(r).ParseAll()
 	if err != nil {
 		return 0, fmt.Errorf("loading input: %w", err)
 	}
@@ -1,4 +1,22 @@
 package day2
 
+import (
+	"io"
+
+	"adv2025/aoc/registry"
+)
+
 // Parts contains all implemented parts for this day
 var Parts = []func(string) (int, error){Part1, Part2}
+
+// ReaderParts holds the same parts as Parts, reading input from an io.Reader
+var ReaderParts = []func(io.Reader) (int, error){Part1FromReader, Part2FromReader}
+
+// init registers every part with the runner
+func init() {
+	for i, part := range Parts {
+		registry.Register(2, i+1, part)
+		registry.RegisterReader(2, i+1, ReaderParts[i])
+	}
+	registry.RegisterProgress(2, 2, Part2Progress)
+}
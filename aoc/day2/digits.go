@@ -0,0 +1,84 @@
+package day2
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// digitBuffer holds the decimal digits of a counter that is advanced in place.
+//
+// Consecutive IDs share all but their last few digits, so incrementing the
+// ASCII digits directly (with carry) replaces a strconv.Itoa allocation per ID
+// with, on average, a single byte update.
+type digitBuffer struct {
+	digits []byte
+}
+
+func newDigitBuffer(n int) *digitBuffer {
+	return &digitBuffer{digits: []byte(strconv.Itoa(n))}
+}
+
+// Increment adds one, growing the buffer when the carry overflows (999 -> 1000)
+func (b *digitBuffer) Increment() {
+	for i := len(b.digits) - 1; i >= 0; i-- {
+		if b.digits[i] < '9' {
+			b.digits[i]++
+			return
+		}
+		b.digits[i] = '0'
+	}
+	b.digits = append([]byte{'1'}, b.digits...)
+}
+
+// Bytes returns the current digits; the slice is only valid until the next Increment
+func (b *digitBuffer) Bytes() []byte {
+	return b.digits
+}
+
+// isRepeatedPattern is AtLeastTwiceValidator's check on a digit slice
+func isRepeatedPattern(s []byte) bool {
+	if len(s) == 0 || s[0] == '0' {
+		return false
+	}
+
+	n := len(s)
+	for patternLen := 1; patternLen <= n/2; patternLen++ {
+		if n%patternLen != 0 {
+			continue
+		}
+
+		pattern := s[:patternLen]
+		isRepeating := true
+		for i := patternLen; i < n; i += patternLen {
+			if !bytes.Equal(s[i:i+patternLen], pattern) {
+				isRepeating = false
+				break
+			}
+		}
+
+		if isRepeating {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sumRepeatedFast sums the at-least-twice IDs in the ranges using a digit buffer
+func sumRepeatedFast(ranges []Range) int {
+	sum := 0
+	for _, r := range ranges {
+		if r.Start > r.End {
+			continue
+		}
+
+		buf := newDigitBuffer(r.Start)
+		for id := r.Start; id <= r.End; id++ {
+			if isRepeatedPattern(buf.Bytes()) {
+				sum += id
+			}
+			buf.Increment()
+		}
+	}
+	return sum
+}
@@ -0,0 +1,60 @@
+package day2
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestDigitBufferIncrement(t *testing.T) {
+	buf := newDigitBuffer(0)
+	for want := 0; want <= 100_000; want++ {
+		if got := string(buf.Bytes()); got != strconv.Itoa(want) {
+			t.Fatalf("expected %d, got %s", want, got)
+		}
+		buf.Increment()
+	}
+}
+
+func TestPart2FastParity(t *testing.T) {
+	ranges := []Range{{1, 2_000_000}, {999_990, 1_000_010}, {5, 3}}
+	validator := AtLeastTwiceValidator{}
+
+	if got, want := sumRepeatedFast(ranges), sumInvalid(ranges, validator); got != want {
+		t.Errorf("fast sum %d differs from validator sum %d", got, want)
+	}
+}
+
+func TestPart2FastMatchesPart2(t *testing.T) {
+	path := writeInput(t, "11-22,95-115,998-1012,1188511880-1188511890,222220-222224,1698522-1698528")
+
+	want, err := Part2(path)
+	if err != nil {
+		t.Fatalf("Part2: %v", err)
+	}
+	got, err := Part2Fast(path)
+	if err != nil {
+		t.Fatalf("Part2Fast: %v", err)
+	}
+	if got != want {
+		t.Errorf("expected %d, got %d", want, got)
+	}
+}
+
+func benchmarkRanges() []Range {
+	return []Range{{1, 1_000_000}, {7_693_600_637, 7_693_779_967}}
+}
+
+func BenchmarkPart2Validator(b *testing.B) {
+	ranges := benchmarkRanges()
+	validator := AtLeastTwiceValidator{}
+	for b.Loop() {
+		sumInvalid(ranges, validator)
+	}
+}
+
+func BenchmarkPart2Fast(b *testing.B) {
+	ranges := benchmarkRanges()
+	for b.Loop() {
+		sumRepeatedFast(ranges)
+	}
+}
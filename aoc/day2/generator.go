@@ -0,0 +1,95 @@
+package day2
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// Invalid IDs are just a block of digits written k >= 2 times, so instead of
+// testing every ID in a range they can be generated directly: for an n-digit
+// ID and a block length p dividing n, the ID is block * (1 + 10^p + 10^2p + ...),
+// and only the blocks whose product lands inside the range need visiting.
+// The work is proportional to the number of invalid IDs, not the range size.
+
+// doubledIDs yields, in ascending order, the IDs in r written as a block
+// exactly twice (ExactlyTwiceValidator's rule)
+func doubledIDs(r Range) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for n := digitCount(max(r.Start, 1)); n <= digitCount(r.End); n++ {
+			if n%2 != 0 {
+				continue
+			}
+			for id := range blockRepeats(r, n, n/2) {
+				if !yield(id) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// repeatedIDs yields, in ascending order, the IDs in r written as a block at
+// least twice (AtLeastTwiceValidator's rule).
+//
+// One ID can be built from several block lengths - 111111 is "1" x6, "11" x3
+// and "111" x2 - so each digit count's candidates are sorted and deduplicated
+// before being yielded, and every ID appears exactly once.
+func repeatedIDs(r Range) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for n := digitCount(max(r.Start, 1)); n <= digitCount(r.End); n++ {
+			var ids []int
+			for p := 1; p <= n/2; p++ {
+				if n%p == 0 {
+					ids = slices.AppendSeq(ids, blockRepeats(r, n, p))
+				}
+			}
+
+			slices.Sort(ids)
+			for _, id := range slices.Compact(ids) {
+				if !yield(id) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// blockRepeats yields, in ascending order, the n-digit IDs in r made of a
+// p-digit block (no leading zero) repeated n/p times
+func blockRepeats(r Range, n, p int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		multiplier := 0
+		for range n / p {
+			multiplier = multiplier*pow10(p) + 1
+		}
+
+		lo := max(r.Start, pow10(n-1))
+		hi := min(r.End, largestWithDigits(n))
+		if lo > hi {
+			return
+		}
+
+		first := max(pow10(p-1), (lo-1)/multiplier+1) // ceil(lo / multiplier)
+		last := min(pow10(p)-1, hi/multiplier)
+		for block := first; block <= last; block++ {
+			if !yield(block * multiplier) {
+				return
+			}
+		}
+	}
+}
+
+// digitCount returns the number of decimal digits in a non-negative n
+func digitCount(n int) int {
+	return len(strconv.Itoa(n))
+}
+
+// largestWithDigits returns the largest n-digit number that fits in an int
+func largestWithDigits(n int) int {
+	if n >= digitCount(math.MaxInt) {
+		return math.MaxInt
+	}
+	return pow10(n) - 1
+}
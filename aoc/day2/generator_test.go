@@ -0,0 +1,82 @@
+package day2
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// naiveIDs collects the invalid IDs in r by testing every number
+func naiveIDs(r Range, v Validator) []int {
+	var ids []int
+	for id := r.Start; id <= r.End; id++ {
+		if v.IsInvalid(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestGeneratorsMatchNaiveLoop(t *testing.T) {
+	tests := []Range{
+		{11, 22},
+		{95, 115},
+		{998, 1012},
+		{1, 9},           // no single-digit repeats
+		{100000, 200000}, // 111111 reachable from three block lengths
+		{222220, 222224},
+		{1188511880, 1188511890},
+		{824824821, 824824827},
+		{2121212118, 2121212124},
+		{55, 55},
+		{56, 54}, // empty
+		{0, 1100},
+	}
+	for _, r := range tests {
+		if got, want := slices.Collect(doubledIDs(r)), naiveIDs(r, ExactlyTwiceValidator{}); !slices.Equal(got, want) {
+			t.Errorf("doubledIDs(%v): expected %v, got %v", r, want, got)
+		}
+		if got, want := slices.Collect(repeatedIDs(r)), naiveIDs(r, AtLeastTwiceValidator{}); !slices.Equal(got, want) {
+			t.Errorf("repeatedIDs(%v): expected %v, got %v", r, want, got)
+		}
+	}
+}
+
+func TestGeneratorsMatchNaiveLoopRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(2025))
+	for range 200 {
+		start := rng.Intn(10_000_000)
+		r := Range{start, start + rng.Intn(20_000)}
+
+		if got, want := slices.Collect(doubledIDs(r)), naiveIDs(r, ExactlyTwiceValidator{}); !slices.Equal(got, want) {
+			t.Fatalf("doubledIDs(%v): expected %v, got %v", r, want, got)
+		}
+		if got, want := slices.Collect(repeatedIDs(r)), naiveIDs(r, AtLeastTwiceValidator{}); !slices.Equal(got, want) {
+			t.Fatalf("repeatedIDs(%v): expected %v, got %v", r, want, got)
+		}
+	}
+}
+
+func TestDoubledIDsWideRange(t *testing.T) {
+	// Every block of 1-5 digits doubles into exactly one ID below 10^10
+	count := 0
+	for range doubledIDs(Range{1, 9_999_999_999}) {
+		count++
+	}
+	if count != 99999 {
+		t.Errorf("expected 99999 doubled IDs, got %d", count)
+	}
+}
+
+func TestGeneratorsStopEarly(t *testing.T) {
+	var got []int
+	for id := range repeatedIDs(Range{1, 1_000_000}) {
+		got = append(got, id)
+		if len(got) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{11, 22, 33}) {
+		t.Errorf("expected the first three repeats, got %v", got)
+	}
+}
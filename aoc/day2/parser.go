@@ -77,23 +77,63 @@ func parseRanges(line string) ([]Range, error) {
 			continue
 		}
 
-		nums := strings.Split(part, "-")
-		if len(nums) != 2 {
-			return nil, fmt.Errorf("invalid range format: %s", part)
+		r, err := parseRange(part)
+		if err != nil {
+			return nil, err
 		}
+		ranges = append(ranges, r)
+	}
 
-		start, err := strconv.Atoi(strings.TrimSpace(nums[0]))
-		if err != nil {
-			return nil, fmt.Errorf("invalid start number: %w", err)
+	return ranges, nil
+}
+
+// parseRange parses a single range like "11-22"
+func parseRange(part string) (Range, error) {
+	nums := strings.Split(part, "-")
+	if len(nums) != 2 {
+		return Range{}, fmt.Errorf("invalid range format: %s", part)
+	}
+
+	start, err := strconv.Atoi(strings.TrimSpace(nums[0]))
+	if err != nil {
+		return Range{}, fmt.Errorf("invalid start number: %w", err)
+	}
+
+	end, err := strconv.Atoi(strings.TrimSpace(nums[1]))
+	if err != nil {
+		return Range{}, fmt.Errorf("invalid end number: %w", err)
+	}
+
+	return Range{Start: start, End: end}, nil
+}
+
+// streamRanges parses comma-separated ranges incrementally, invoking fn for each one.
+// Unlike parseRanges it never holds the whole line in memory, so inputs with
+// millions of ranges can be processed in constant space.
+func streamRanges(r io.Reader, fn func(Range) error) error {
+	reader := bufio.NewReader(r)
+	index := 0
+
+	for {
+		token, readErr := reader.ReadString(',')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("reading input: %w", readErr)
 		}
 
-		end, err := strconv.Atoi(strings.TrimSpace(nums[1]))
-		if err != nil {
-			return nil, fmt.Errorf("invalid end number: %w", err)
+		part := strings.TrimSpace(strings.TrimSuffix(token, ","))
+		if part != "" {
+			index++
+			rng, err := parseRange(part)
+			if err != nil {
+				return fmt.Errorf("range %d: %w", index, err)
+			}
+			if err := fn(rng); err != nil {
+				return fmt.Errorf("range %d: %w", index, err)
+			}
 		}
 
-		ranges = append(ranges, Range{Start: start, End: end})
+		if readErr == io.EOF {
+			return nil
+		}
 	}
-
-	return ranges, nil
 }
@@ -0,0 +1,146 @@
+package day2
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStreamRangesManyRanges(t *testing.T) {
+	const n = 100000
+	var sb strings.Builder
+	for i := range n {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, "%d-%d", i*10, i*10+5)
+	}
+	sb.WriteString(",\n") // trailing comma and newline are tolerated
+
+	calls := 0
+	err := streamRanges(strings.NewReader(sb.String()), func(r Range) error {
+		if r.Start != calls*10 || r.End != calls*10+5 {
+			return fmt.Errorf("unexpected range %v", r)
+		}
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("streaming failed: %v", err)
+	}
+	if calls != n {
+		t.Errorf("expected %d callbacks, got %d", n, calls)
+	}
+}
+
+func TestStreamRangesMatchesParseRanges(t *testing.T) {
+	line := "11-22,95-115,998-1012"
+
+	expected, err := parseRanges(line)
+	if err != nil {
+		t.Fatalf("parseRanges failed: %v", err)
+	}
+
+	var streamed []Range
+	err = streamRanges(strings.NewReader(line), func(r Range) error {
+		streamed = append(streamed, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("streamRanges failed: %v", err)
+	}
+
+	if len(streamed) != len(expected) {
+		t.Fatalf("expected %d ranges, got %d", len(expected), len(streamed))
+	}
+	for i := range expected {
+		if streamed[i] != expected[i] {
+			t.Errorf("range %d: expected %v, got %v", i, expected[i], streamed[i])
+		}
+	}
+}
+
+func TestStreamRangesInvalid(t *testing.T) {
+	err := streamRanges(strings.NewReader("1-2,oops"), func(Range) error { return nil })
+	if err == nil {
+		t.Fatal("expected an error for malformed range")
+	}
+}
+
+func TestPartsFromReader(t *testing.T) {
+	const input = "11-22,95-115,998-1012,1188511880-1188511890,222220-222224,1698522-1698528,446443-446449,38593856-38593862,565653-565659,824824821-824824827,2121212118-2121212124\n"
+
+	part1, err := Part1FromReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Part1FromReader: unexpected error: %v", err)
+	}
+	if part1 != 1227775554 {
+		t.Errorf("Part1FromReader: expected 1227775554, got %d", part1)
+	}
+
+	part2, err := Part2FromReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Part2FromReader: unexpected error: %v", err)
+	}
+	if part2 != 4174379265 {
+		t.Errorf("Part2FromReader: expected 4174379265, got %d", part2)
+	}
+
+	if _, err := Part1FromReader(strings.NewReader("")); err == nil {
+		t.Error("Part1FromReader: expected an error for empty input")
+	}
+	if _, err := Part2FromReader(strings.NewReader("")); err == nil {
+		t.Error("Part2FromReader: expected an error for empty input")
+	}
+}
+
+func TestPartsAcceptLongLines(t *testing.T) {
+	// one line well past bufio.Scanner's 64 KB token limit
+	var sb strings.Builder
+	var ranges []Range
+	for sb.Len() < 200_000 {
+		r := Range{Start: 1000 + len(ranges)*100, End: 1000 + len(ranges)*100 + 50}
+		fmt.Fprintf(&sb, "%d-%d,", r.Start, r.End)
+		ranges = append(ranges, r)
+	}
+	sb.WriteString("\n")
+
+	for name, tt := range map[string]struct {
+		solve     func(io.Reader) (int, error)
+		validator Validator
+	}{
+		"Part1FromReader": {Part1FromReader, ExactlyTwiceValidator{}},
+		"Part2FromReader": {Part2FromReader, AtLeastTwiceValidator{}},
+	} {
+		got, err := tt.solve(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Errorf("%s: unexpected error on a %d-byte line: %v", name, sb.Len(), err)
+			continue
+		}
+		if want := sumInvalid(ranges, tt.validator); got != want {
+			t.Errorf("%s: expected %d, got %d", name, want, got)
+		}
+	}
+}
+
+func TestPart2Progress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("11-22,95-115,998-1012\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var reports [][2]int
+	got, err := Part2Progress(path, func(done, total int) {
+		reports = append(reports, [2]int{done, total})
+	})
+	if err != nil || got != 11+22+99+111+999+1010 {
+		t.Fatalf("expected the Part2 sum, got %d, %v", got, err)
+	}
+	if want := [][2]int{{1, 3}, {2, 3}, {3, 3}}; !slices.Equal(reports, want) {
+		t.Errorf("expected progress %v, got %v", want, reports)
+	}
+}
@@ -1,57 +1,55 @@
 package day2
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"adv2025/aoc/input"
+)
 
 // Part1 solves Day 2 Part 1: sum all invalid product IDs in the given ranges.
 //
 // Problem: Find IDs that are patterns repeated exactly twice (e.g., 123123, 55, 6464)
 //
 // Algorithm Analysis:
-// - Must check each ID individually - no mathematical shortcut exists
-// - Can't skip ranges: pattern 123123 appears scattered throughout
-// - Can't generate patterns: would need to enumerate all patterns then check ranges
-//
-// Why this approach is actually efficient:
-// 1. Validator.IsInvalid() is O(log n) where n is the ID value (digit count)
-// 2. String comparison in Go is highly optimized (memcmp)
-// 3. Early exits in validator for odd-length and mismatches
-// 4. Strategy pattern allows swapping validators without changing this code
+//   - An invalid ID is a block written twice: 6464 = 64 * 101, 123123 = 123 * 1001
+//   - So they can be generated instead of searched for: for each even digit count,
+//     only the blocks whose doubled value lands inside the range are visited
+//     (see doubledIDs in generator.go)
+//   - Work is O(number of invalid IDs), independent of how wide the range is;
+//     a 50M-wide range costs the same as one that holds the same few IDs
 //
-// Performance: ~56ms for millions of IDs is reasonable
-// - Each ID check: ~50-100ns (string conversion + comparison)
-// - Alternative approaches (pattern generation, math) are actually slower
-// - This is as fast as "check every number" can get in Go
+// The Validator strategies remain the reference rules: the generator is tested
+// against a brute-force loop with ExactlyTwiceValidator over small ranges.
 //
-// Potential micro-optimizations (not worth the complexity):
-// - Parallel goroutines per range (overhead > benefit for this problem)
-// - Digit-by-digit validation (requires complex logic, slower than string ops)
-// - Caching (patterns don't repeat enough to matter)
+// Ranges are streamed straight from the input (see streamRanges) so the sum is
+// accumulated without ever materializing the full range list.
 func Part1(inputPath string) (int, error) {
-	parser, err := FromFile(inputPath)
-	if err != nil {
-		return 0, fmt.Errorf("loading input: %w", err)
-	}
-
-	ranges, err := parser.ParseAll()
-	if err != nil {
-		return 0, fmt.Errorf("parsing ranges: %w", err)
-	}
+	return input.SolveFile(inputPath, Part1FromReader)
+}
 
-	// Strategy pattern: validator encapsulates the validation logic
-	// This keeps Part1 focused on iteration, validator focused on rules
-	validator := ExactlyTwiceValidator{}
+// Part1FromReader is Part1 for ranges read from r
+func Part1FromReader(r io.Reader) (int, error) {
 	sum := 0
+	rangeCount := 0
 
-	// Iterate through all IDs in all ranges
-	// Time complexity: O(total_range_size * log(max_id))
+	// Time complexity: O(invalid IDs in the ranges)
 	// Space complexity: O(1) - only accumulator
-	for _, r := range ranges {
-		for id := r.Start; id <= r.End; id++ {
-			if validator.IsInvalid(id) {
-				sum += id
-			}
+	err := streamRanges(r, func(rng Range) error {
+		rangeCount++
+		for id := range doubledIDs(rng) {
+			sum += id
 		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("parsing ranges: %w", err)
+	}
+	if rangeCount == 0 {
+		return 0, fmt.Errorf("loading input: empty input file")
 	}
+	slog.Debug("day2: ranges summed", "part", 1, "ranges", rangeCount)
 
 	return sum, nil
 }
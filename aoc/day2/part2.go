@@ -1,43 +1,61 @@
 package day2
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"adv2025/aoc/input"
+	"adv2025/aoc/registry"
+)
 
 // Part2 solves Day 2 Part 2: sum all invalid product IDs with relaxed rules.
 //
 // Problem: Find IDs that are patterns repeated at least twice (e.g., 111, 123123, 55)
 //
-// Same algorithmic approach as Part1, but with different validation rules.
-// See Part1 comments for detailed analysis of why iteration is optimal here.
-//
-// Strategy Pattern Benefit: We only changed the validator, not the iteration logic.
-// Part1 and Part2 share the same structure but different behavior - classic OOP pattern.
-//
-// Performance: Similar to Part1 (~56ms)
-// - AtLeastTwiceValidator is slightly more complex (tries multiple pattern lengths)
-// - Still O(log n) per ID check (where n is the ID value)
-// - Early exits keep average case fast
+// Same generate-don't-scan approach as Part1 (see the analysis there), with
+// every block length p that divides the digit count instead of only n/2.
+// repeatedIDs deduplicates IDs reachable from several block lengths, so
+// 111111 ("1" x6, "11" x3, "111" x2) is summed once - matching
+// AtLeastTwiceValidator exactly.
 func Part2(inputPath string) (int, error) {
-	parser, err := FromFile(inputPath)
-	if err != nil {
-		return 0, fmt.Errorf("loading input: %w", err)
-	}
+	return input.SolveFile(inputPath, Part2FromReader)
+}
 
-	ranges, err := parser.ParseAll()
+// Part2FromReader is Part2 for ranges read from r
+func Part2FromReader(r io.Reader) (int, error) {
+	return part2(r, func(int, int) {})
+}
+
+// Part2Progress is Part2 reporting each finished range to progress
+func Part2Progress(inputPath string, progress registry.Progress) (int, error) {
+	return input.SolveFile(inputPath, func(r io.Reader) (int, error) {
+		return part2(r, progress)
+	})
+}
+
+// part2 reads the ranges with the same streamRanges as Part1, so both parts
+// accept the same inputs, but keeps them: progress needs the total up front
+func part2(r io.Reader, progress registry.Progress) (int, error) {
+	var ranges []Range
+	err := streamRanges(r, func(rng Range) error {
+		ranges = append(ranges, rng)
+		return nil
+	})
 	if err != nil {
 		return 0, fmt.Errorf("parsing ranges: %w", err)
 	}
+	if len(ranges) == 0 {
+		return 0, fmt.Errorf("loading input: empty input file")
+	}
+	slog.Debug("day2: ranges parsed", "part", 2, "ranges", len(ranges))
 
-	// Different validator, same iteration pattern
-	// This demonstrates the power of the Strategy pattern
-	validator := AtLeastTwiceValidator{}
 	sum := 0
-
-	for _, r := range ranges {
-		for id := r.Start; id <= r.End; id++ {
-			if validator.IsInvalid(id) {
-				sum += id
-			}
+	for i, rng := range ranges {
+		for id := range repeatedIDs(rng) {
+			sum += id
 		}
+		progress(i+1, len(ranges))
 	}
 
 	return sum, nil
@@ -0,0 +1,42 @@
+package day2
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// EstimateInvalidSum estimates the sum of Part2-invalid IDs in r by checking
+// samples uniformly random IDs instead of every ID in the range.
+//
+// Each sample contributes id when it is invalid and 0 otherwise, so the sample
+// mean estimates (fraction invalid) * (average invalid ID); multiplying by the
+// range size extrapolates to the whole range. The assumptions behind this:
+//   - IDs are drawn independently and uniformly, with replacement, so the
+//     estimate is unbiased but its error only shrinks like 1/sqrt(samples).
+//   - Invalid IDs are rare (roughly one per 10^(digits/2) IDs), so a range needs
+//     enough samples to hit many of them; with only a handful of hits the
+//     estimate is dominated by which ones happened to be drawn, and with none
+//     it is 0.
+//
+// The same seed always yields the same estimate.
+func EstimateInvalidSum(r Range, samples int, seed int64) (float64, error) {
+	if samples <= 0 {
+		return 0, fmt.Errorf("sample count must be positive, got %d", samples)
+	}
+	if r.Start > r.End {
+		return 0, fmt.Errorf("range %d-%d is empty", r.Start, r.End)
+	}
+
+	size := int64(r.End) - int64(r.Start) + 1
+	rng := rand.New(rand.NewSource(seed))
+	validator := AtLeastTwiceValidator{}
+
+	total := 0.0
+	for range samples {
+		id := r.Start + int(rng.Int63n(size))
+		if validator.IsInvalid(id) {
+			total += float64(id)
+		}
+	}
+	return total / float64(samples) * float64(size), nil
+}
@@ -0,0 +1,33 @@
+package day2
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateInvalidSum(t *testing.T) {
+	r := Range{Start: 10, End: 9999} // 108 invalid IDs among 9990
+	exact := sumInvalid([]Range{r}, AtLeastTwiceValidator{})
+
+	estimate, err := EstimateInvalidSum(r, 200_000, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if relErr := math.Abs(estimate-float64(exact)) / float64(exact); relErr > 0.1 {
+		t.Errorf("estimate %.0f is more than 10%% off the exact sum %d", estimate, exact)
+	}
+
+	again, _ := EstimateInvalidSum(r, 200_000, 1)
+	if again != estimate {
+		t.Errorf("same seed should reproduce the estimate: %.0f vs %.0f", estimate, again)
+	}
+}
+
+func TestEstimateInvalidSumInvalidArgs(t *testing.T) {
+	if _, err := EstimateInvalidSum(Range{Start: 1, End: 100}, 0, 1); err == nil {
+		t.Error("expected an error for zero samples")
+	}
+	if _, err := EstimateInvalidSum(Range{Start: 100, End: 1}, 10, 1); err == nil {
+		t.Error("expected an error for an empty range")
+	}
+}
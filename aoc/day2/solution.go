@@ -0,0 +1,98 @@
+package day2
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// loadRanges opens an input file and parses every range in it
+func loadRanges(inputPath string) ([]Range, error) {
+	parser, err := FromFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading input: %w", err)
+	}
+
+	ranges, err := parser.ParseAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing ranges: %w", err)
+	}
+	return ranges, nil
+}
+
+// invalidIDs yields every ID in the ranges that the validator flags as invalid,
+// range by range in input order
+func invalidIDs(ranges []Range, validator Validator) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, r := range ranges {
+			for id := r.Start; id <= r.End; id++ {
+				if validator.IsInvalid(id) && !yield(id) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// sumInvalid sums every ID in the ranges that the validator flags as invalid
+func sumInvalid(ranges []Range, validator Validator) int {
+	sum := 0
+	for id := range invalidIDs(ranges, validator) {
+		sum += id
+	}
+	return sum
+}
+
+// sumInvalidMod sums the invalid IDs under a modulus. Both operands are kept
+// below modulus, and the addition wraps by subtracting instead of computing
+// sum+x, which overflows once modulus is above MaxInt/2.
+func sumInvalidMod(ranges []Range, validator Validator, modulus int) int {
+	sum := 0
+	for id := range invalidIDs(ranges, validator) {
+		x := id % modulus
+		if sum >= modulus-x {
+			sum -= modulus - x
+		} else {
+			sum += x
+		}
+	}
+	return sum
+}
+
+// mergeRanges returns the ranges sorted by start with overlapping or adjacent ranges combined
+func mergeRanges(ranges []Range) []Range {
+	sorted := slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b Range) int { return a.Start - b.Start })
+
+	var merged []Range
+	for _, r := range sorted {
+		if n := len(merged); n > 0 && r.Start <= merged[n-1].End+1 {
+			merged[n-1].End = max(merged[n-1].End, r.End)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// solveWith loads the ranges from a file and sums the IDs flagged by the validator
+func solveWith(inputPath string, validator Validator) (int, error) {
+	ranges, err := loadRanges(inputPath)
+	if err != nil {
+		return 0, err
+	}
+	return sumInvalid(ranges, validator), nil
+}
+
+// solveWithMod is solveWith with the sum reduced modulo modulus
+func solveWithMod(inputPath string, validator Validator, modulus int) (int, error) {
+	if modulus <= 0 {
+		return 0, fmt.Errorf("modulus must be positive, got %d", modulus)
+	}
+
+	ranges, err := loadRanges(inputPath)
+	if err != nil {
+		return 0, err
+	}
+	return sumInvalidMod(ranges, validator, modulus), nil
+}
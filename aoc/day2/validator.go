@@ -74,3 +74,111 @@ func (v AtLeastTwiceValidator) IsInvalid(id int) bool {
 
 	return false
 }
+
+// MonodigitValidator checks if an ID is a single digit repeated, like 7777
+// Every monodigit ID is also caught by AtLeastTwiceValidator (pattern length 1)
+type MonodigitValidator struct{}
+
+// IsInvalid returns true if the ID has at least two digits and all of them are identical
+func (v MonodigitValidator) IsInvalid(id int) bool {
+	return isMonodigit(id)
+}
+
+// isMonodigit reports whether id has at least two digits, all equal
+func isMonodigit(id int) bool {
+	if id < 10 {
+		return false
+	}
+
+	last := id % 10
+	for id > 0 {
+		if id%10 != last {
+			return false
+		}
+		id /= 10
+	}
+	return true
+}
+
+// smallestInvalid returns the smallest digits-digit ID that AtLeastTwiceValidator
+// flags, or -1 when digits < 2 (no single-digit ID repeats a pattern).
+//
+// A digits-digit repeat is a block of length p (a proper divisor of digits)
+// repeated digits/p times. The smallest block of length p is 10^(p-1), and the
+// longest block keeps the most zeros in front of the next 1, so the answer is
+// 10^(p-1) repeated with p the largest proper divisor: 11, 111, 1010, 100100.
+func smallestInvalid(digits int) int {
+	if digits < 2 {
+		return -1
+	}
+
+	blockLen := 1
+	for p := digits / 2; p >= 1; p-- {
+		if digits%p == 0 {
+			blockLen = p
+			break
+		}
+	}
+
+	block := pow10(blockLen - 1)
+	id := 0
+	for range digits / blockLen {
+		id = id*pow10(blockLen) + block
+	}
+	return id
+}
+
+// largestInvalid returns the largest digits-digit ID that AtLeastTwiceValidator
+// flags, or -1 when digits < 2. The all-nines ID (99, 999, 9999) is "9" repeated,
+// and nothing with the same digit count is larger.
+func largestInvalid(digits int) int {
+	if digits < 2 {
+		return -1
+	}
+	return pow10(digits) - 1
+}
+
+// pow10 returns 10^n for small non-negative n
+func pow10(n int) int {
+	result := 1
+	for range n {
+		result *= 10
+	}
+	return result
+}
+
+// isNearInvalid reports whether id is valid under AtLeastTwiceValidator but
+// changing exactly one of its digits would make it invalid, e.g. 121 -> 111 or
+// 1011 -> 1010. Replacements that would introduce a leading zero don't count.
+func isNearInvalid(id int) bool {
+	if id < 0 {
+		return false
+	}
+
+	digits := []byte(strconv.Itoa(id))
+	if isRepeatedPattern(digits) {
+		return false
+	}
+
+	for i, original := range digits {
+		for d := byte('0'); d <= '9'; d++ {
+			if d == original || (i == 0 && d == '0') {
+				continue
+			}
+			digits[i] = d
+			near := isRepeatedPattern(digits)
+			digits[i] = original
+			if near {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nearInvalidValidator adapts isNearInvalid to the Validator interface
+type nearInvalidValidator struct{}
+
+func (v nearInvalidValidator) IsInvalid(id int) bool {
+	return isNearInvalid(id)
+}
@@ -0,0 +1,172 @@
+package day2
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeInput writes a test input line to a temporary file and returns its path.
+func writeInput(t *testing.T, line string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(line+"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write input: %v", err)
+	}
+	return path
+}
+
+func TestIsMonodigit(t *testing.T) {
+	tests := []struct {
+		id   int
+		want bool
+	}{
+		{7, false},
+		{11, true},
+		{777, true},
+		{7777, true},
+		{7778, false},
+		{1010, false},
+	}
+	for _, tt := range tests {
+		if got := isMonodigit(tt.id); got != tt.want {
+			t.Errorf("isMonodigit(%d): expected %v, got %v", tt.id, tt.want, got)
+		}
+	}
+}
+
+func TestMonodigitAgreesWithPart2Rule(t *testing.T) {
+	atLeastTwice := AtLeastTwiceValidator{}
+	exactlyTwice := ExactlyTwiceValidator{}
+
+	for id := 10; id <= 99999; id++ {
+		if isMonodigit(id) && !atLeastTwice.IsInvalid(id) {
+			t.Errorf("monodigit %d is not invalid under the part2 rule", id)
+		}
+	}
+
+	if !isMonodigit(777) || exactlyTwice.IsInvalid(777) {
+		t.Error("expected 777 to be monodigit but valid under the exactly-twice rule")
+	}
+}
+
+func TestPart1MonodigitVsParts(t *testing.T) {
+	path := writeInput(t, "770-780,5555-5555")
+
+	mono, err := Part1Monodigit(path)
+	if err != nil {
+		t.Fatalf("Part1Monodigit: %v", err)
+	}
+	part1, err := Part1(path)
+	if err != nil {
+		t.Fatalf("Part1: %v", err)
+	}
+	part2, err := Part2(path)
+	if err != nil {
+		t.Fatalf("Part2: %v", err)
+	}
+
+	if mono != 777+5555 {
+		t.Errorf("Part1Monodigit: expected %d, got %d", 777+5555, mono)
+	}
+	if part2 != mono {
+		t.Errorf("Part2 should agree with Part1Monodigit on monodigit-only ranges: %d vs %d", part2, mono)
+	}
+	if part1 != 5555 {
+		t.Errorf("Part1 should only count the even-length 5555, got %d", part1)
+	}
+}
+
+func TestSmallestLargestInvalid(t *testing.T) {
+	tests := []struct {
+		digits            int
+		smallest, largest int
+	}{
+		{2, 11, 99},
+		{3, 111, 999},
+		{4, 1010, 9999},
+		{6, 100100, 999999},
+	}
+	validator := AtLeastTwiceValidator{}
+
+	for _, tt := range tests {
+		small, large := smallestInvalid(tt.digits), largestInvalid(tt.digits)
+		if small != tt.smallest || large != tt.largest {
+			t.Errorf("%d digits: expected [%d, %d], got [%d, %d]", tt.digits, tt.smallest, tt.largest, small, large)
+		}
+		if !validator.IsInvalid(small) || !validator.IsInvalid(large) {
+			t.Errorf("%d digits: %d and %d should both be invalid", tt.digits, small, large)
+		}
+	}
+
+	if smallestInvalid(1) != -1 || largestInvalid(1) != -1 {
+		t.Error("expected -1 for single-digit IDs")
+	}
+}
+
+func TestSmallestLargestInvalidAreExtremes(t *testing.T) {
+	validator := AtLeastTwiceValidator{}
+
+	for digits := 2; digits <= 6; digits++ {
+		lo, hi := pow10(digits-1), pow10(digits)-1
+		first, last := -1, -1
+		for id := lo; id <= hi; id++ {
+			if validator.IsInvalid(id) {
+				if first < 0 {
+					first = id
+				}
+				last = id
+			}
+		}
+		if first != smallestInvalid(digits) || last != largestInvalid(digits) {
+			t.Errorf("%d digits: brute force found [%d, %d], generator gave [%d, %d]",
+				digits, first, last, smallestInvalid(digits), largestInvalid(digits))
+		}
+	}
+}
+
+func TestIsNearInvalid(t *testing.T) {
+	// Hand-checked over 100-125: 3-digit repeats are monodigits, so a near miss
+	// has two equal digits and one odd one out. 100 only "fixes" to 000, which
+	// has a leading zero, and 111 is already invalid.
+	expected := []int{101, 110, 112, 113, 114, 115, 116, 117, 118, 119, 121, 122}
+
+	var got []int
+	for id := 100; id <= 125; id++ {
+		if isNearInvalid(id) {
+			got = append(got, id)
+		}
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected near misses %v, got %v", expected, got)
+	}
+
+	for _, tc := range []struct {
+		id   int
+		want bool
+	}{
+		{7, false},    // no single-digit ID can become a repeat
+		{12, true},    // 12 -> 11 or 22
+		{1011, true},  // 1011 -> 1010
+		{1234, false}, // needs two changes to reach 1212
+		{1212, false}, // already invalid
+	} {
+		if got := isNearInvalid(tc.id); got != tc.want {
+			t.Errorf("isNearInvalid(%d) = %v, want %v", tc.id, got, tc.want)
+		}
+	}
+}
+
+func TestPart2NearMiss(t *testing.T) {
+	path := writeInput(t, "100-125")
+
+	got, err := Part2NearMiss(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 101 + 110 + 112 + 113 + 114 + 115 + 116 + 117 + 118 + 119 + 121 + 122; got != want {
+		t.Errorf("expected %d, got %d", want, got)
+	}
+}
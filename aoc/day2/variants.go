@@ -0,0 +1,99 @@
+package day2
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// maxListedIDs caps how many IDs InvalidIDs returns so an unlimited request
+// over astronomically large ranges cannot exhaust memory
+const maxListedIDs = 1_000_000
+
+// Part1Monodigit sums all IDs made of a single repeated digit (11, 777, 9999).
+//
+// This is a strict subset of Part2's rule: a monodigit ID is a length-1 pattern
+// repeated len(id) times. Part1's exactly-twice rule only agrees for even
+// lengths, so odd-length IDs like 777 are counted here and in Part2 but not Part1.
+func Part1Monodigit(inputPath string) (int, error) {
+	return solveWith(inputPath, MonodigitValidator{})
+}
+
+// InvalidIDs returns up to limit invalid IDs (under Part2's at-least-twice rule)
+// in ascending order across all ranges. Overlapping ranges are merged first, so
+// every ID appears at most once.
+//
+// A limit of 0 means unlimited, which is still capped at maxListedIDs.
+func InvalidIDs(inputPath string, limit int) ([]int, error) {
+	ranges, err := loadRanges(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxListedIDs {
+		limit = maxListedIDs
+	}
+
+	var ids []int
+	for id := range invalidIDs(mergeRanges(ranges), AtLeastTwiceValidator{}) {
+		ids = append(ids, id)
+		if len(ids) == limit {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// Part1Span sums the Part1-invalid IDs in the single range start-end (inclusive)
+// without reading a file
+func Part1Span(start, end int) (int, error) {
+	if start > end {
+		return 0, fmt.Errorf("invalid span %d-%d: start exceeds end", start, end)
+	}
+	return sumInvalid([]Range{{Start: start, End: end}}, ExactlyTwiceValidator{}), nil
+}
+
+// Part1Mod solves Part1 with the sum reported modulo modulus (e.g. 1e9+7)
+func Part1Mod(inputPath string, modulus int) (int, error) {
+	return solveWithMod(inputPath, ExactlyTwiceValidator{}, modulus)
+}
+
+// Part2Mod solves Part2 with the sum reported modulo modulus (e.g. 1e9+7)
+func Part2Mod(inputPath string, modulus int) (int, error) {
+	return solveWithMod(inputPath, AtLeastTwiceValidator{}, modulus)
+}
+
+// Part2Fast solves Part2 by scanning, without reformatting every ID: each
+// range is walked with a digitBuffer that increments its digits in place (see
+// digits.go). That makes it about twice as fast as the AtLeastTwiceValidator
+// loop, but it still visits every ID: Part2 generates the invalid IDs instead
+// and is far faster on wide ranges. Results are identical to Part2.
+func Part2Fast(inputPath string) (int, error) {
+	ranges, err := loadRanges(inputPath)
+	if err != nil {
+		return 0, err
+	}
+	return sumRepeatedFast(ranges), nil
+}
+
+// Part2NearMiss sums the IDs that are one digit change away from being invalid
+// under Part2's rule (see isNearInvalid). Every digit of every ID is tried
+// against all replacements, so this is noticeably slower than Part2.
+func Part2NearMiss(inputPath string) (int, error) {
+	return solveWith(inputPath, nearInvalidValidator{})
+}
+
+// InvalidByLength buckets the invalid IDs (Part2's rule) by digit count,
+// returning digitCount -> number of invalid IDs with that many digits.
+// Overlapping ranges are merged first, so each ID is counted once.
+func InvalidByLength(inputPath string) (map[int]int, error) {
+	ranges, err := loadRanges(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	histogram := make(map[int]int)
+	for id := range invalidIDs(mergeRanges(ranges), AtLeastTwiceValidator{}) {
+		histogram[len(strconv.Itoa(id))]++
+	}
+	return histogram, nil
+}
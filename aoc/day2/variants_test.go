@@ -0,0 +1,131 @@
+package day2
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestInvalidIDs(t *testing.T) {
+	// Out of order and overlapping: 95-115 and 100-120 merge, 11-22 sorts first
+	path := writeInput(t, "95-115,11-22,100-120,998-1012")
+
+	ids, err := InvalidIDs(path, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []int{11, 22, 99, 111, 999, 1010}
+	if !slices.Equal(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestInvalidIDsLimit(t *testing.T) {
+	path := writeInput(t, "11-22,95-115,998-1012")
+
+	ids, err := InvalidIDs(path, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []int{11, 22, 99}
+	if !slices.Equal(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestPartModMatchesPartsBelowModulus(t *testing.T) {
+	const modulus = 1_000_000_007
+	path := writeInput(t, "11-22,95-115,998-1012,1188511880-1188511890")
+
+	for name, parts := range map[string][2]func(string) (int, error){
+		"Part1": {Part1, func(p string) (int, error) { return Part1Mod(p, modulus) }},
+		"Part2": {Part2, func(p string) (int, error) { return Part2Mod(p, modulus) }},
+	} {
+		want, err := parts[0](path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got, err := parts[1](path)
+		if err != nil {
+			t.Fatalf("%sMod: %v", name, err)
+		}
+		if got != want%modulus {
+			t.Errorf("%sMod: expected %d, got %d", name, want%modulus, got)
+		}
+	}
+}
+
+func TestPartModReduces(t *testing.T) {
+	path := writeInput(t, "11-22")
+
+	got, err := Part1Mod(path, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != (11+22)%10 {
+		t.Errorf("expected %d, got %d", (11+22)%10, got)
+	}
+
+	if _, err := Part2Mod(path, 0); err == nil {
+		t.Error("expected an error for a zero modulus")
+	}
+}
+
+func TestSumInvalidModNearMaxInt(t *testing.T) {
+	// two 19-digit repdigits whose plain sum overflows int
+	ranges := []Range{
+		{Start: 7777777777777777777, End: 7777777777777777777},
+		{Start: 8888888888888888888, End: 8888888888888888888},
+	}
+	if got, want := sumInvalidMod(ranges, AtLeastTwiceValidator{}, math.MaxInt), 7443294629811890858; got != want {
+		t.Errorf("expected %d, got %d", want, got)
+	}
+	if got, want := sumInvalidMod(ranges, AtLeastTwiceValidator{}, math.MaxInt/2+2), 2831608611384502950; got != want {
+		t.Errorf("just above MaxInt/2: expected %d, got %d", want, got)
+	}
+}
+
+func TestInvalidByLength(t *testing.T) {
+	// 11..99 (9), 111..999 (9), then 1010 and 1111 below 1200
+	path := writeInput(t, "10-1200,50-60")
+
+	histogram, err := InvalidByLength(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[int]int{2: 9, 3: 9, 4: 2}
+	if !maps.Equal(histogram, expected) {
+		t.Errorf("expected %v, got %v", expected, histogram)
+	}
+}
+
+func TestPart1Span(t *testing.T) {
+	tests := []struct {
+		start, end int
+		want       int
+	}{
+		{11, 22, 11 + 22},
+		{95, 115, 99},
+		{998, 1012, 1010},
+		{1, 9, 0},
+		{1188511880, 1188511890, 1188511885},
+		{55, 55, 55},
+	}
+	for _, tt := range tests {
+		got, err := Part1Span(tt.start, tt.end)
+		if err != nil {
+			t.Fatalf("Part1Span(%d, %d): unexpected error: %v", tt.start, tt.end, err)
+		}
+		if got != tt.want {
+			t.Errorf("Part1Span(%d, %d): expected %d, got %d", tt.start, tt.end, tt.want, got)
+		}
+	}
+
+	if _, err := Part1Span(20, 10); err == nil {
+		t.Error("expected an error when start exceeds end")
+	}
+}
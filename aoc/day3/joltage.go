@@ -0,0 +1,43 @@
+package day3
+
+// Batteries switched on per bank in each part of the puzzle
+const (
+	part1Batteries = 2
+	part2Batteries = 12
+)
+
+// maxJoltage returns the largest k-digit joltage that can be formed by
+// switching on k batteries of the bank, keeping them in their original order.
+// Banks shorter than k (and k <= 0) produce 0.
+//
+// Monotonic Stack Greedy: exactly len(bank)-k digits have to be dropped. Scan
+// left to right keeping the chosen digits on a stack; while drops remain and
+// the incoming digit beats the top of the stack, the top is dropped - a larger
+// digit one place earlier always wins, whatever follows. Equal digits are kept
+// (dropping one would only waste a drop). Any drops left at the end come off
+// the tail, which holds the smallest trailing digits.
+//
+// Time complexity: O(n) - every digit is pushed and popped at most once
+// Space complexity: O(n) for the stack
+func maxJoltage(bank string, k int) int {
+	if k <= 0 || len(bank) < k {
+		return 0
+	}
+
+	drops := len(bank) - k
+	stack := make([]byte, 0, len(bank))
+	for i := 0; i < len(bank); i++ {
+		digit := bank[i]
+		for drops > 0 && len(stack) > 0 && stack[len(stack)-1] < digit {
+			stack = stack[:len(stack)-1]
+			drops--
+		}
+		stack = append(stack, digit)
+	}
+
+	joltage := 0
+	for _, digit := range stack[:k] {
+		joltage = joltage*10 + int(digit-'0')
+	}
+	return joltage
+}
@@ -0,0 +1,86 @@
+package day3
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// bruteForceJoltage tries every k-subset of the bank's batteries in order and
+// returns the largest joltage, or 0 when fewer than k batteries exist
+func bruteForceJoltage(bank string, k int) int {
+	if k <= 0 || len(bank) < k {
+		return 0
+	}
+
+	best := 0
+	var pick func(from, left, value int)
+	pick = func(from, left, value int) {
+		if left == 0 {
+			best = max(best, value)
+			return
+		}
+		for i := from; i <= len(bank)-left; i++ {
+			pick(i+1, left-1, value*10+int(bank[i]-'0'))
+		}
+	}
+	pick(0, k, 0)
+	return best
+}
+
+func TestMaxJoltage(t *testing.T) {
+	tests := []struct {
+		bank string
+		k    int
+		want int
+	}{
+		{"987654321111111", 2, 98},
+		{"811111111111119", 2, 89},
+		{"234234234234278", 2, 78},
+		{"818181911112111", 2, 92},
+		{"987654321111111", 12, 987654321111},
+		{"811111111111119", 12, 811111111119},
+		{"234234234234278", 12, 434234234278},
+		{"818181911112111", 12, 888911112111},
+		// ties: equal digits are all kept
+		{"99999", 3, 999},
+		{"9899", 2, 99},
+		// a small leading digit is dropped for a larger one behind it
+		{"19", 1, 9},
+		{"1999", 3, 999},
+		// drops left over come off the tail
+		{"54321", 3, 543},
+		// exactly k digits: the bank itself
+		{"0123", 4, 123},
+		// banks shorter than k
+		{"5", 2, 0},
+		{"", 1, 0},
+		{"123", 0, 0},
+	}
+	for _, tt := range tests {
+		if got := maxJoltage(tt.bank, tt.k); got != tt.want {
+			t.Errorf("maxJoltage(%q, %d): expected %d, got %d", tt.bank, tt.k, tt.want, got)
+		}
+	}
+}
+
+func TestMaxJoltageMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(2025))
+
+	for range 2000 {
+		n := rng.Intn(13)
+		// a small digit alphabet forces plenty of ties
+		alphabet := 2 + rng.Intn(9)
+		var sb strings.Builder
+		for range n {
+			sb.WriteByte(byte('0' + rng.Intn(alphabet)))
+		}
+		bank := sb.String()
+
+		for k := range n + 2 {
+			if got, want := maxJoltage(bank, k), bruteForceJoltage(bank, k); got != want {
+				t.Fatalf("maxJoltage(%q, %d): expected %d, got %d", bank, k, want, got)
+			}
+		}
+	}
+}
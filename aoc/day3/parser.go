@@ -10,14 +10,30 @@ import (
 
 // BankParser reads and parses battery banks from input
 type BankParser struct {
-	scanner *bufio.Scanner
+	scanner        *bufio.Scanner
+	spaceSeparated bool
+}
+
+// BankParserOption configures optional BankParser behavior
+type BankParserOption func(*BankParser)
+
+// WithSpaceSeparated accepts banks written as space-separated single digits ("1 2 3 4").
+// Each whitespace-separated token must be exactly one digit; runs of spaces are allowed.
+func WithSpaceSeparated() BankParserOption {
+	return func(p *BankParser) {
+		p.spaceSeparated = true
+	}
 }
 
 // NewBankParser creates a parser from an io.Reader
-func NewBankParser(r io.Reader) *BankParser {
-	return &BankParser{
+func NewBankParser(r io.Reader, opts ...BankParserOption) *BankParser {
+	p := &BankParser{
 		scanner: bufio.NewScanner(r),
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // ParseAll reads all battery banks from the input
@@ -32,6 +48,14 @@ func (p *BankParser) ParseAll() ([]string, error) {
 			continue
 		}
 
+		if p.spaceSeparated {
+			joined, err := joinDigitTokens(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			line = joined
+		}
+
 		// Validate that the line contains only digits
 		for _, ch := range line {
 			if ch < '0' || ch > '9' {
@@ -49,14 +73,29 @@ func (p *BankParser) ParseAll() ([]string, error) {
 	return banks, nil
 }
 
+// joinDigitTokens collapses space-separated single-digit tokens into a compact bank
+func joinDigitTokens(line string) (string, error) {
+	tokens := strings.Fields(line)
+
+	var sb strings.Builder
+	sb.Grow(len(tokens))
+	for _, token := range tokens {
+		if len(token) != 1 {
+			return "", fmt.Errorf("invalid token %q, expected a single digit", token)
+		}
+		sb.WriteString(token)
+	}
+	return sb.String(), nil
+}
+
 // FromFile creates a parser from a file path and parses all banks immediately
-func FromFile(path string) ([]string, error) {
+func FromFile(path string, opts ...BankParserOption) ([]string, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("opening file: %w", err)
 	}
 	defer file.Close()
 
-	parser := NewBankParser(file)
+	parser := NewBankParser(file, opts...)
 	return parser.ParseAll()
 }
@@ -0,0 +1,63 @@
+package day3
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSpaceSeparatedBanks(t *testing.T) {
+	input := "1 2 3 4\n9  8   7 6 5\n"
+
+	banks, err := NewBankParser(strings.NewReader(input), WithSpaceSeparated()).ParseAll()
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	if expected := []string{"1234", "98765"}; !slices.Equal(banks, expected) {
+		t.Fatalf("expected %v, got %v", expected, banks)
+	}
+	if got, want := findMaxJoltageFreq(banks[0]), findMaxJoltageFreq("1234"); got != want {
+		t.Errorf("expected spaced bank to yield %d, got %d", want, got)
+	}
+}
+
+func TestSpaceSeparatedRejectsMultiDigitTokens(t *testing.T) {
+	if _, err := NewBankParser(strings.NewReader("12 3 4"), WithSpaceSeparated()).ParseAll(); err == nil {
+		t.Error("expected an error for a multi-digit token")
+	}
+}
+
+func TestCompactFormatIsDefault(t *testing.T) {
+	banks, err := NewBankParser(strings.NewReader("1234\n")).ParseAll()
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if !slices.Equal(banks, []string{"1234"}) {
+		t.Errorf("expected [1234], got %v", banks)
+	}
+
+	if _, err := NewBankParser(strings.NewReader("1 2 3 4\n")).ParseAll(); err == nil {
+		t.Error("expected spaces to be rejected without WithSpaceSeparated")
+	}
+}
+
+func TestPartsFromReader(t *testing.T) {
+	const input = "987654321111111\n811111111111119\n234234234234278\n818181911112111\n"
+
+	part1, err := Part1FromReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Part1FromReader: unexpected error: %v", err)
+	}
+	if part1 != 357 {
+		t.Errorf("Part1FromReader: expected 357, got %d", part1)
+	}
+
+	part2, err := Part2FromReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Part2FromReader: unexpected error: %v", err)
+	}
+	if part2 != 3121910778619 {
+		t.Errorf("Part2FromReader: expected 3121910778619, got %d", part2)
+	}
+}
@@ -1,24 +1,83 @@
 package day3
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"adv2025/aoc/input"
+)
 
 // Part1 solves Day 3 Part 1: find the maximum joltage from each battery bank
 // and return the total output joltage
 func Part1(inputPath string) (int, error) {
-	banks, err := FromFile(inputPath)
+	return input.SolveFile(inputPath, Part1FromReader)
+}
+
+// Part1FromReader is Part1 for input read from r
+func Part1FromReader(r io.Reader) (int, error) {
+	banks, err := NewBankParser(r).ParseAll()
 	if err != nil {
 		return 0, fmt.Errorf("loading input: %w", err)
 	}
+	slog.Debug("day3: banks parsed", "part", 1, "banks", len(banks), "batteries", part1Batteries)
 
 	totalJoltage := 0
 	for _, bank := range banks {
-		maxJoltage := findMaxJoltage(bank)
-		totalJoltage += maxJoltage
+		totalJoltage += maxJoltage(bank, part1Batteries)
 	}
 
 	return totalJoltage, nil
 }
 
+// findMaxJoltageFreq finds the maximum two-digit joltage using digit occurrence tables.
+//
+// A single pass records the first and last index of every digit. The best
+// answer uses the largest tens digit that still has some digit after it, and
+// for that tens digit the largest units digit whose last occurrence lies after
+// the tens digit's first occurrence. Both searches run over the 10 digit
+// values rather than the bank, so no nested loop touches the input.
+//
+// Time complexity: O(n + 10*10)
+// Space complexity: O(1) - two fixed-size tables
+//
+// Part1 now uses the general maxJoltage (k = 2); this specialised version and
+// findMaxJoltage are kept as reference implementations for cross-checking.
+func findMaxJoltageFreq(bank string) int {
+	if len(bank) < 2 {
+		return 0
+	}
+
+	first, last := digitOccurrences(bank)
+	for tens := 9; tens >= 0; tens-- {
+		if first[tens] < 0 {
+			continue
+		}
+		for units := 9; units >= 0; units-- {
+			if last[units] > first[tens] {
+				return tens*10 + units
+			}
+		}
+	}
+
+	return 0
+}
+
+// digitOccurrences returns the first and last index of each digit in the bank (-1 if absent)
+func digitOccurrences(bank string) (first, last [10]int) {
+	for d := range first {
+		first[d], last[d] = -1, -1
+	}
+	for i := 0; i < len(bank); i++ {
+		digit := bank[i] - '0'
+		if first[digit] < 0 {
+			first[digit] = i
+		}
+		last[digit] = i
+	}
+	return first, last
+}
+
 // findMaxJoltage finds the maximum two-digit joltage from a battery bank
 // by selecting any two batteries (maintaining their order).
 //
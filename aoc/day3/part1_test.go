@@ -0,0 +1,44 @@
+package day3
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestFindMaxJoltageFreqExamples(t *testing.T) {
+	tests := []struct {
+		bank string
+		want int
+	}{
+		{"987654321111111", 98},
+		{"811111111111119", 89},
+		{"234234234234278", 78},
+		{"818181911112111", 92},
+		{"73924", 94},
+		{"19", 19},
+		{"5", 0},
+	}
+	for _, tt := range tests {
+		if got := findMaxJoltageFreq(tt.bank); got != tt.want {
+			t.Errorf("findMaxJoltageFreq(%q): expected %d, got %d", tt.bank, tt.want, got)
+		}
+	}
+}
+
+func TestFindMaxJoltageFreqMatchesReference(t *testing.T) {
+	rng := rand.New(rand.NewSource(2025))
+
+	for range 5000 {
+		n := 2 + rng.Intn(30)
+		var sb strings.Builder
+		for range n {
+			sb.WriteByte(byte('0' + rng.Intn(10)))
+		}
+		bank := sb.String()
+
+		if got, want := findMaxJoltageFreq(bank), findMaxJoltage(bank); got != want {
+			t.Fatalf("bank %q: frequency method got %d, reference got %d", bank, got, want)
+		}
+	}
+}
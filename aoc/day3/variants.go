@@ -0,0 +1,146 @@
+package day3
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Part2Distinct sums each bank's maximum two-digit joltage when the two
+// batteries must hold different digit values
+func Part2Distinct(inputPath string) (int, error) {
+	banks, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	totalJoltage := 0
+	for _, bank := range banks {
+		totalJoltage += maxJoltageDistinct(bank)
+	}
+
+	return totalJoltage, nil
+}
+
+// maxJoltageDistinct finds the maximum two-digit joltage with digit1 != digit2,
+// keeping the batteries in their original order. Returns 0 when the bank holds
+// fewer than two distinct values (e.g. "9999").
+//
+// Same occurrence-table idea as findMaxJoltageFreq: the largest tens digit is
+// taken at its first occurrence, paired with the largest different units digit
+// that appears after it.
+func maxJoltageDistinct(bank string) int {
+	first, last := digitOccurrences(bank)
+
+	for tens := 9; tens >= 0; tens-- {
+		if first[tens] < 0 {
+			continue
+		}
+		for units := 9; units >= 0; units-- {
+			if units != tens && last[units] > first[tens] {
+				return tens*10 + units
+			}
+		}
+	}
+
+	return 0
+}
+
+// Part2Adjacent sums each bank's maximum joltage when the two batteries must
+// sit next to each other
+func Part2Adjacent(inputPath string) (int, error) {
+	banks, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	totalJoltage := 0
+	for _, bank := range banks {
+		totalJoltage += maxAdjacentJoltage(bank)
+	}
+
+	return totalJoltage, nil
+}
+
+// maxAdjacentJoltage finds the maximum two-digit joltage formed by batteries
+// at positions (i, i+1). Unlike findMaxJoltage, the pair can't skip anything in
+// between, so "9119" scores 91 rather than 99. Returns 0 for banks shorter than 2.
+func maxAdjacentJoltage(bank string) int {
+	maxJoltage := 0
+	for i := 0; i+1 < len(bank); i++ {
+		joltage := int(bank[i]-'0')*10 + int(bank[i+1]-'0')
+		maxJoltage = max(maxJoltage, joltage)
+	}
+	return maxJoltage
+}
+
+// Part2TopBanks sums the Part2 (12-battery) joltage of only the n best banks.
+// When n exceeds the number of banks, every bank contributes.
+func Part2TopBanks(inputPath string, n int) (int, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("bank count must be non-negative, got %d", n)
+	}
+
+	banks, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	joltages := make([]int, len(banks))
+	for i, bank := range banks {
+		joltages[i] = maxJoltage(bank, part2Batteries)
+	}
+	slices.SortFunc(joltages, func(a, b int) int { return b - a })
+
+	totalJoltage := 0
+	for _, joltage := range joltages[:min(n, len(joltages))] {
+		totalJoltage += joltage
+	}
+
+	return totalJoltage, nil
+}
+
+// checksumConfig holds the optional settings for Part2Checksum
+type checksumConfig struct {
+	xor bool
+}
+
+// ChecksumOption configures how Part2Checksum folds the per-bank joltages
+type ChecksumOption func(*checksumConfig)
+
+// WithXORFold folds the per-bank joltages with XOR instead of adding them
+func WithXORFold() ChecksumOption {
+	return func(c *checksumConfig) {
+		c.xor = true
+	}
+}
+
+// Part2Checksum folds every bank's Part2 (12-battery) joltage into a checksum
+// reduced modulo mod. By default the fold is a sum, kept reduced as it goes so
+// it cannot overflow; WithXORFold switches to an XOR fold.
+func Part2Checksum(inputPath string, mod int, opts ...ChecksumOption) (int, error) {
+	if mod <= 0 {
+		return 0, fmt.Errorf("modulus must be positive, got %d", mod)
+	}
+
+	var config checksumConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	banks, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	checksum := 0
+	for _, bank := range banks {
+		joltage := maxJoltage(bank, part2Batteries)
+		if config.xor {
+			checksum ^= joltage
+		} else {
+			checksum = (checksum + joltage%mod) % mod
+		}
+	}
+
+	return checksum % mod, nil
+}
@@ -0,0 +1,146 @@
+package day3
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeBanks writes test banks to a temporary input file and returns its path.
+func writeBanks(t *testing.T, banks string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(banks), 0o644); err != nil {
+		t.Fatalf("failed to write input: %v", err)
+	}
+	return path
+}
+
+func TestMaxJoltageDistinct(t *testing.T) {
+	tests := []struct {
+		bank string
+		want int
+	}{
+		{"1991", 91}, // unconstrained best is 99
+		{"99", 0},    // only one distinct value
+		{"9999", 0},
+		{"9899", 98},
+		{"29", 29},
+		{"92", 92},
+		{"5", 0},
+	}
+	for _, tt := range tests {
+		if got := maxJoltageDistinct(tt.bank); got != tt.want {
+			t.Errorf("maxJoltageDistinct(%q): expected %d, got %d", tt.bank, tt.want, got)
+		}
+	}
+}
+
+func TestPart2Distinct(t *testing.T) {
+	path := writeBanks(t, "1991\n99\n9899\n")
+
+	got, err := Part2Distinct(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 91 + 0 + 98; got != want {
+		t.Errorf("expected %d, got %d", want, got)
+	}
+}
+
+func TestMaxAdjacentJoltage(t *testing.T) {
+	tests := []struct {
+		bank string
+		want int
+	}{
+		{"9119", 91}, // any-pair best is 99
+		{"1929", 92}, // any-pair best is 99
+		{"818181911112111", 91},
+		{"987654321111111", 98}, // agrees with the any-pair rule
+		{"5", 0},
+	}
+	for _, tt := range tests {
+		if got := maxAdjacentJoltage(tt.bank); got != tt.want {
+			t.Errorf("maxAdjacentJoltage(%q): expected %d, got %d", tt.bank, tt.want, got)
+		}
+		if len(tt.bank) >= 2 && maxAdjacentJoltage(tt.bank) > findMaxJoltage(tt.bank) {
+			t.Errorf("maxAdjacentJoltage(%q) beats the unconstrained any-pair rule", tt.bank)
+		}
+	}
+}
+
+func TestPart2Adjacent(t *testing.T) {
+	path := writeBanks(t, "9119\n1929\n987654321111111\n")
+
+	got, err := Part2Adjacent(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 91 + 92 + 98; got != want {
+		t.Errorf("expected %d, got %d", want, got)
+	}
+}
+
+func TestPart2TopBanks(t *testing.T) {
+	// Part2 joltages: 987654321111, 811111111119, 434234234278, 888911112111
+	path := writeBanks(t, "987654321111111\n811111111111119\n234234234234278\n818181911112111\n")
+
+	got, err := Part2TopBanks(path, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 987654321111 + 888911112111; got != want {
+		t.Errorf("expected %d, got %d", want, got)
+	}
+
+	all, err := Part2TopBanks(path, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	part2, err := Part2(path)
+	if err != nil {
+		t.Fatalf("Part2: %v", err)
+	}
+	if all != part2 {
+		t.Errorf("expected n larger than the bank count to match Part2 (%d), got %d", part2, all)
+	}
+}
+
+func TestPart2Checksum(t *testing.T) {
+	// Part2 joltages: 987654321111, 811111111119, 434234234278, 888911112111
+	path := writeBanks(t, "987654321111111\n811111111111119\n234234234234278\n818181911112111\n")
+	joltages := []int{987654321111, 811111111119, 434234234278, 888911112111}
+
+	sum, err := Part2Checksum(path, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (11 + 19 + 78 + 11) % 100; sum != want {
+		t.Errorf("modular sum: expected %d, got %d", want, sum)
+	}
+
+	const bigMod = 1_000_000_007
+	if got, _ := Part2Checksum(path, bigMod); got != 3121910778619%bigMod {
+		t.Errorf("modular sum: expected %d, got %d", 3121910778619%bigMod, got)
+	}
+
+	xor := 0
+	for _, j := range joltages {
+		xor ^= j
+	}
+	got, err := Part2Checksum(path, bigMod, WithXORFold())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := xor % bigMod; got != want {
+		t.Errorf("xor fold: expected %d, got %d", want, got)
+	}
+	if got, _ := Part2Checksum(path, 1<<62, WithXORFold()); got != xor {
+		t.Errorf("xor fold with a huge modulus: expected %d, got %d", xor, got)
+	}
+
+	if _, err := Part2Checksum(path, 0); err == nil {
+		t.Error("expected an error for a zero modulus")
+	}
+}
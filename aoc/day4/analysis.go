@@ -0,0 +1,139 @@
+package day4
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GridInfo reports the shape of the grid and the fraction of its cells that are rolls.
+//
+// Profiling Helper: a quick look at size and density is often enough to pick an
+// algorithm variant (dense grids favor worklists, sparse grids favor scans).
+// Ragged rows are normalized first, so cols is the widest row and density is
+// measured against the full rows x cols rectangle.
+func GridInfo(inputPath string) (rows, cols int, rollCount int, density float64, err error) {
+	grid, err := FromFile(inputPath, WithNormalize())
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	rows = len(grid)
+	if rows > 0 {
+		cols = len(grid[0])
+	}
+	for _, line := range grid {
+		rollCount += strings.Count(line, "@")
+	}
+	if cells := rows * cols; cells > 0 {
+		density = float64(rollCount) / float64(cells)
+	}
+
+	return rows, cols, rollCount, density, nil
+}
+
+// neighborCountGrid returns, for every cell, how many of its 8 neighbors are
+// rolls - a heatmap of crowding computed in a single pass over the grid.
+func neighborCountGrid(lines []string) [][]int {
+	grid := toMutableGrid(lines)
+	counts := make([][]int, len(grid))
+	for row := range grid {
+		counts[row] = make([]int, len(grid[row]))
+		for col := range grid[row] {
+			counts[row][col] = countNeighbors(grid, row, col)
+		}
+	}
+	return counts
+}
+
+// ThresholdForFraction returns the smallest accessibility threshold T such
+// that at least fraction of the rolls are accessible, where a roll is
+// accessible under T when it has fewer than T neighboring rolls (Part1 uses 4).
+//
+// Calibration Helper: neighbor counts are computed once and bucketed into a
+// histogram, so every candidate threshold is answered by a running sum rather
+// than rescanning the grid. T ranges over 0..9; T = 9 admits every roll since
+// no roll has more than 8 neighbors.
+func ThresholdForFraction(inputPath string, fraction float64) (int, error) {
+	if fraction < 0 || fraction > 1 {
+		return 0, fmt.Errorf("fraction %v outside [0, 1]", fraction)
+	}
+
+	grid, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	var histogram [9]int // histogram[n] = rolls with exactly n neighbors
+	rolls := 0
+	for row, counts := range neighborCountGrid(grid) {
+		for col, n := range counts {
+			if grid[row][col] == '@' {
+				histogram[n]++
+				rolls++
+			}
+		}
+	}
+	if rolls == 0 {
+		return 0, fmt.Errorf("grid contains no rolls")
+	}
+
+	accessible := 0 // rolls with fewer than threshold neighbors
+	for threshold := 0; threshold < len(histogram); threshold++ {
+		if float64(accessible) >= fraction*float64(rolls) {
+			return threshold, nil
+		}
+		accessible += histogram[threshold]
+	}
+	return len(histogram), nil
+}
+
+// compassDirections names the 8 neighbor offsets, clockwise from north
+var compassDirections = []struct {
+	name   string
+	dr, dc int
+}{
+	{"N", -1, 0}, {"NE", -1, 1}, {"E", 0, 1}, {"SE", 1, 1},
+	{"S", 1, 0}, {"SW", 1, -1}, {"W", 0, -1}, {"NW", -1, -1},
+}
+
+// ExplainAccessibility describes why the roll at (row, col) is or isn't accessible,
+// listing which compass directions hold neighboring rolls, e.g.
+//
+//	roll at (3,4) has 5 adjacent rolls (N,NE,E,S,SW); not accessible (threshold 4)
+func ExplainAccessibility(inputPath string, row, col int) (string, error) {
+	grid, err := FromFile(inputPath)
+	if err != nil {
+		return "", fmt.Errorf("loading input: %w", err)
+	}
+
+	if !inBounds(grid, position{row, col}) {
+		return "", fmt.Errorf("(%d,%d) is outside the grid", row, col)
+	}
+	if grid[row][col] != '@' {
+		return "", fmt.Errorf("(%d,%d) is not a roll", row, col)
+	}
+
+	var occupied []string
+	for _, dir := range compassDirections {
+		n := position{row + dir.dr, col + dir.dc}
+		if inBounds(grid, n) && grid[n.row][n.col] == '@' {
+			occupied = append(occupied, dir.name)
+		}
+	}
+
+	noun := "rolls"
+	if len(occupied) == 1 {
+		noun = "roll"
+	}
+	detail := ""
+	if len(occupied) > 0 {
+		detail = " (" + strings.Join(occupied, ",") + ")"
+	}
+	verdict := "accessible"
+	if len(occupied) >= accessThreshold {
+		verdict = "not accessible"
+	}
+
+	return fmt.Sprintf("roll at (%d,%d) has %d adjacent %s%s; %s (threshold %d)",
+		row, col, len(occupied), noun, detail, verdict, accessThreshold), nil
+}
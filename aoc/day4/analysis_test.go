@@ -0,0 +1,122 @@
+package day4
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGridInfo(t *testing.T) {
+	path := writeGrid(t, "@@..\n@...\n@@@@")
+
+	rows, cols, rolls, density, err := GridInfo(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rows != 3 || cols != 4 {
+		t.Errorf("expected 3x4 grid, got %dx%d", rows, cols)
+	}
+	if rolls != 7 {
+		t.Errorf("expected 7 rolls, got %d", rolls)
+	}
+	if want := 7.0 / 12.0; density != want {
+		t.Errorf("expected density %v, got %v", want, density)
+	}
+}
+
+func TestGridInfoRagged(t *testing.T) {
+	path := writeGrid(t, "@@@@\n@")
+
+	rows, cols, rolls, density, err := GridInfo(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rows != 2 || cols != 4 || rolls != 5 || density != 5.0/8.0 {
+		t.Errorf("unexpected info: rows=%d cols=%d rolls=%d density=%v", rows, cols, rolls, density)
+	}
+}
+
+// accessibleFraction is the naive reference for ThresholdForFraction
+func accessibleFraction(grid []string, threshold int) float64 {
+	counts := neighborCountGrid(grid)
+	rolls, accessible := 0, 0
+	for row := range grid {
+		for col := range grid[row] {
+			if grid[row][col] == '@' {
+				rolls++
+				if counts[row][col] < threshold {
+					accessible++
+				}
+			}
+		}
+	}
+	return float64(accessible) / float64(rolls)
+}
+
+func TestThresholdForFraction(t *testing.T) {
+	lines := []string{"@@@@@", "@@@@@", "@@@@@"}
+	path := writeGrid(t, strings.Join(lines, "\n"))
+
+	for _, fraction := range []float64{0.1, 0.25, 0.5, 0.6, 0.9, 1} {
+		threshold, err := ThresholdForFraction(path, fraction)
+		if err != nil {
+			t.Fatalf("fraction %v: unexpected error: %v", fraction, err)
+		}
+
+		if got := accessibleFraction(lines, threshold); got < fraction {
+			t.Errorf("fraction %v: threshold %d only admits %v", fraction, threshold, got)
+		}
+		if threshold > 0 && accessibleFraction(lines, threshold-1) >= fraction {
+			t.Errorf("fraction %v: threshold %d is not minimal", fraction, threshold)
+		}
+	}
+}
+
+func TestThresholdForFractionKnownValue(t *testing.T) {
+	// 3x5 block: 4 corners have 3 neighbors, 8 edge cells have 5, 3 interior cells have 8
+	path := writeGrid(t, "@@@@@\n@@@@@\n@@@@@")
+
+	threshold, err := ThresholdForFraction(path, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if threshold != 6 {
+		t.Errorf("expected threshold 6 (12 of 15 rolls), got %d", threshold)
+	}
+
+	if _, err := ThresholdForFraction(path, 1.5); err == nil {
+		t.Error("expected an error for a fraction above 1")
+	}
+}
+
+func TestExplainAccessibility(t *testing.T) {
+	path := writeGrid(t, strings.Join([]string{
+		"..@@.",
+		"...@.",
+		"..@@.",
+		".@...",
+	}, "\n"))
+
+	tests := []struct {
+		row, col int
+		want     string
+	}{
+		{1, 3, "roll at (1,3) has 4 adjacent rolls (N,S,SW,NW); not accessible (threshold 4)"},
+		{0, 2, "roll at (0,2) has 2 adjacent rolls (E,SE); accessible (threshold 4)"},
+		{3, 1, "roll at (3,1) has 1 adjacent roll (NE); accessible (threshold 4)"},
+	}
+	for _, tt := range tests {
+		got, err := ExplainAccessibility(path, tt.row, tt.col)
+		if err != nil {
+			t.Fatalf("(%d,%d): unexpected error: %v", tt.row, tt.col, err)
+		}
+		if got != tt.want {
+			t.Errorf("(%d,%d):\nexpected %q\ngot      %q", tt.row, tt.col, tt.want, got)
+		}
+	}
+
+	if _, err := ExplainAccessibility(path, 0, 0); err == nil {
+		t.Error("expected an error for an empty cell")
+	}
+}
@@ -0,0 +1,43 @@
+package day4
+
+// Rule decides a cell's next state from its current state and how many of its
+// 8 neighbors are rolls ('@').
+type Rule func(neighbors int, current byte) byte
+
+// removalRule is Part2's rule: an accessible roll (fewer than 4 neighbors) is removed.
+func removalRule(neighbors int, current byte) byte {
+	if current == '@' && neighbors < accessThreshold {
+		return '.'
+	}
+	return current
+}
+
+// Step advances the grid one generation by applying rule to every cell
+// simultaneously and returns how many cells changed.
+//
+// Double Buffering: every neighbor count is taken from the current generation
+// and the new states are written to a separate buffer, which is copied back
+// once the whole grid is evaluated. Updating in place would let early cells
+// see half of the next generation - the classic cellular automaton bug.
+//
+// The grid is modified in place, so callers can loop until Step returns 0
+// (a fixed point) - the same state Part2's removal loop reaches.
+func Step(grid [][]byte, rule Rule) int {
+	next := make([][]byte, len(grid))
+	changed := 0
+
+	for row := range grid {
+		next[row] = make([]byte, len(grid[row]))
+		for col, current := range grid[row] {
+			next[row][col] = rule(countNeighbors(grid, row, col), current)
+			if next[row][col] != current {
+				changed++
+			}
+		}
+	}
+
+	for row := range grid {
+		copy(grid[row], next[row])
+	}
+	return changed
+}
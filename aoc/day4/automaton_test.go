@@ -0,0 +1,65 @@
+package day4
+
+import "testing"
+
+// lifeRule is Conway's birth/survival rule: B3/S23
+func lifeRule(neighbors int, current byte) byte {
+	if current == '@' && (neighbors == 2 || neighbors == 3) {
+		return '@'
+	}
+	if current == '.' && neighbors == 3 {
+		return '@'
+	}
+	return '.'
+}
+
+func TestStepBlinker(t *testing.T) {
+	grid := toMutableGrid([]string{
+		".....",
+		".....",
+		".@@@.",
+		".....",
+		".....",
+	})
+
+	changed := Step(grid, lifeRule)
+	if changed != 4 {
+		t.Errorf("expected 4 changed cells, got %d", changed)
+	}
+
+	expected := []string{
+		".....",
+		"..@..",
+		"..@..",
+		"..@..",
+		".....",
+	}
+	for i, row := range grid {
+		if string(row) != expected[i] {
+			t.Errorf("row %d: expected %q, got %q", i, expected[i], row)
+		}
+	}
+
+	// Period 2: a second step restores the horizontal bar
+	Step(grid, lifeRule)
+	if string(grid[2]) != ".@@@." {
+		t.Errorf("expected the blinker to oscillate back, got %q", grid[2])
+	}
+}
+
+func TestStepStillLife(t *testing.T) {
+	grid := toMutableGrid([]string{"....", ".@@.", ".@@.", "...."})
+
+	if changed := Step(grid, lifeRule); changed != 0 {
+		t.Errorf("expected a block to be stable, got %d changes", changed)
+	}
+}
+
+func TestStepRemovalRuleMatchesAccessibleRolls(t *testing.T) {
+	lines := []string{"@@@@@", "@@@@@", "@@@@@"}
+	expected := len(findAccessibleRolls(toMutableGrid(lines)))
+
+	if got := Step(toMutableGrid(lines), removalRule); got != expected {
+		t.Errorf("expected removal step to change %d cells, got %d", expected, got)
+	}
+}
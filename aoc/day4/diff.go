@@ -0,0 +1,42 @@
+package day4
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GridDiff renders the cells that differ between two grid states.
+//
+// Each output row mirrors the input rows: a removed roll is drawn as 'x', a
+// roll that appeared as '+', and every unchanged cell as a space, so a diff of
+// consecutive Part2Snapshots shows exactly one removal round. Grids must have
+// identical dimensions (including per-row widths); anything else is an error
+// rather than a guess at how the rows line up.
+func GridDiff(before, after []string) (string, error) {
+	if len(before) != len(after) {
+		return "", fmt.Errorf("grid heights differ: %d vs %d", len(before), len(after))
+	}
+
+	var sb strings.Builder
+	for row := range before {
+		if len(before[row]) != len(after[row]) {
+			return "", fmt.Errorf("row %d widths differ: %d vs %d", row, len(before[row]), len(after[row]))
+		}
+
+		for col := 0; col < len(before[row]); col++ {
+			switch {
+			case before[row][col] == after[row][col]:
+				sb.WriteByte(' ')
+			case before[row][col] == '@':
+				sb.WriteByte('x')
+			case after[row][col] == '@':
+				sb.WriteByte('+')
+			default:
+				sb.WriteByte('?')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+
+	return sb.String(), nil
+}
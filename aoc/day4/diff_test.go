@@ -0,0 +1,50 @@
+package day4
+
+import "testing"
+
+func TestGridDiffMarksRemovedRolls(t *testing.T) {
+	before := []string{"@@@", "@@@", "..."}
+	after := []string{".@.", "@@@", "..@"}
+
+	diff, err := GridDiff(before, after)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "x x\n   \n  +\n"
+	if diff != expected {
+		t.Errorf("expected diff %q, got %q", expected, diff)
+	}
+}
+
+func TestGridDiffDimensionMismatch(t *testing.T) {
+	if _, err := GridDiff([]string{"@@"}, []string{"@@", ".."}); err == nil {
+		t.Error("expected an error for differing heights")
+	}
+	if _, err := GridDiff([]string{"@@"}, []string{"@@@"}); err == nil {
+		t.Error("expected an error for differing widths")
+	}
+}
+
+func TestGridDiffOfFirstRound(t *testing.T) {
+	path := writeGrid(t, "@@@@@\n@@@@@\n@@@@@")
+
+	snapshots, err := Part2Snapshots(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snapshots) < 2 {
+		t.Fatalf("expected at least one removal round, got %d snapshots", len(snapshots))
+	}
+
+	diff, err := GridDiff(snapshots[0], snapshots[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Round 1 removes exactly the four corners
+	expected := "x   x\n     \nx   x\n"
+	if diff != expected {
+		t.Errorf("expected diff %q, got %q", expected, diff)
+	}
+}
@@ -0,0 +1,42 @@
+package day4
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeGridPadsRaggedRows(t *testing.T) {
+	lines := []string{"@@@", "@", "@@"}
+
+	grid := NormalizeGrid(lines)
+
+	expected := []string{"@@@", "@..", "@@."}
+	for i := range expected {
+		if grid[i] != expected[i] {
+			t.Errorf("row %d: expected %q, got %q", i, expected[i], grid[i])
+		}
+	}
+}
+
+func TestNormalizeDoesNotChangePart1(t *testing.T) {
+	input := "@@@@\n@@\n@@@@@\n@@@\n@"
+
+	ragged, err := NewParser(strings.NewReader(input)).ParseAll()
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	normalized, err := NewParser(strings.NewReader(input), WithNormalize()).ParseAll()
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	for i, row := range normalized {
+		if len(row) != 5 {
+			t.Errorf("row %d: expected width 5, got %d", i, len(row))
+		}
+	}
+
+	if got, want := countAccessible(normalized), countAccessible(ragged); got != want {
+		t.Errorf("expected %d accessible rolls after normalizing, got %d", want, got)
+	}
+}
@@ -18,16 +18,37 @@ type Parser struct {
 	// scanner is unexported (lowercase) - encapsulation principle
 	// Callers interact through methods, not direct field access
 	scanner *bufio.Scanner
+
+	// normalize pads ragged rows into a rectangle after parsing
+	normalize bool
+}
+
+// ParserOption configures optional Parser behavior.
+//
+// Functional Options Pattern: variadic options keep NewParser's signature
+// stable while letting callers opt into extra behavior:
+//   parser := NewParser(r, WithNormalize())
+type ParserOption func(*Parser)
+
+// WithNormalize makes ParseAll return a rectangular grid (see NormalizeGrid).
+func WithNormalize() ParserOption {
+	return func(p *Parser) {
+		p.normalize = true
+	}
 }
 
 // NewParser creates a parser from an io.Reader.
 //
 // Constructor Pattern: New* functions are the idiomatic way to create instances
 // in Go. This allows initialization logic and ensures fields are set correctly.
-func NewParser(r io.Reader) *Parser {
-	return &Parser{
+func NewParser(r io.Reader, opts ...ParserOption) *Parser {
+	p := &Parser{
 		scanner: bufio.NewScanner(r),
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // ParseAll reads all lines from the input.
@@ -68,9 +89,31 @@ func (p *Parser) ParseAll() ([]string, error) {
 		return nil, fmt.Errorf("reading input: %w", err)
 	}
 
+	if p.normalize {
+		return NormalizeGrid(lines), nil
+	}
 	return lines, nil
 }
 
+// NormalizeGrid right-pads shorter rows with '.' so every row has the same width.
+//
+// Ragged rows are already handled by the bounds checks in the neighbor scans
+// (a missing cell behaves like an empty one), so padding never changes which
+// rolls are accessible. A rectangular grid simply lets downstream code assume
+// len(grid[0]) is the width of every row.
+func NormalizeGrid(lines []string) []string {
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line))
+	}
+
+	normalized := make([]string, len(lines))
+	for i, line := range lines {
+		normalized[i] = line + strings.Repeat(".", width-len(line))
+	}
+	return normalized
+}
+
 // FromFile creates a parser from a file path and parses all lines immediately.
 //
 // Convenience Function: Combines common operations (open + parse) into one call.
@@ -79,7 +122,9 @@ func (p *Parser) ParseAll() ([]string, error) {
 // Error Handling: Each layer adds context to errors, making debugging easier:
 //   - os.Open error: "opening file: no such file"
 //   - parser.ParseAll error: "line 5: invalid character 'x'"
-func FromFile(path string) ([]string, error) {
+//
+// Options are forwarded to NewParser, e.g. FromFile(path, WithNormalize()).
+func FromFile(path string, opts ...ParserOption) ([]string, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("opening file: %w", err)
@@ -88,6 +133,6 @@ func FromFile(path string) ([]string, error) {
 	// Critical for resource management - prevents file descriptor leaks
 	defer file.Close()
 
-	parser := NewParser(file)
+	parser := NewParser(file, opts...)
 	return parser.ParseAll()
 }
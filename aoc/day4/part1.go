@@ -1,6 +1,12 @@
 package day4
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"adv2025/aoc/input"
+)
 
 // Part1 solves Day 4 Part 1: count rolls of paper accessible by forklifts.
 //
@@ -17,15 +23,26 @@ import "fmt"
 // - Only checking '@' positions (skip '.')
 // But for this problem size (~140x150), simple iteration is fastest and clearest.
 func Part1(inputPath string) (int, error) {
-	// Delegate parsing to FromFile - separation of concerns
-	// Part1 focuses on solving, not file I/O details
-	grid, err := FromFile(inputPath)
+	return input.SolveFile(inputPath, Part1FromReader)
+}
+
+// Part1FromReader is Part1 for input read from r
+func Part1FromReader(r io.Reader) (int, error) {
+	// Delegate parsing to the Parser - separation of concerns
+	// Part1 focuses on solving, not file I/O details (input.SolveFile handles those)
+	grid, err := NewParser(r).ParseAll()
 	if err != nil {
 		// Error wrapping adds context at each layer
 		// Final error might be: "loading input: opening file: no such file"
 		return 0, fmt.Errorf("loading input: %w", err)
 	}
+	slog.Debug("day4: grid parsed", "rows", len(grid))
 
+	return countAccessible(grid), nil
+}
+
+// countAccessible counts the rolls in the grid that are accessible by forklifts.
+func countAccessible(grid []string) int {
 	count := 0
 	// Nested loop pattern for 2D grid traversal
 	// Time complexity: O(rows * cols * 8) = O(n) where n is total cells
@@ -38,9 +55,13 @@ func Part1(inputPath string) (int, error) {
 		}
 	}
 
-	return count, nil
+	return count
 }
 
+// accessThreshold is the puzzle's crowding limit: a roll with fewer than this
+// many adjacent rolls can be reached by a forklift.
+const accessThreshold = 4
+
 // isAccessible returns true if a roll at (row, col) has fewer than 4 adjacent rolls.
 //
 // Helper Function Pattern: Extract complex logic into named functions for:
@@ -83,5 +104,5 @@ func isAccessible(grid []string, row, col int) bool {
 	}
 
 	// Problem constraint: accessible if FEWER than 4 adjacent (0-3 is accessible)
-	return adjacentCount < 4
+	return adjacentCount < accessThreshold
 }
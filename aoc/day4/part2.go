@@ -1,6 +1,13 @@
 package day4
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"adv2025/aoc/input"
+)
 
 // Part2 solves Day 4 Part 2: iteratively remove accessible rolls.
 //
@@ -8,61 +15,121 @@ import "fmt"
 // become accessible. Keep removing until no more can be removed.
 //
 // This demonstrates several important patterns:
-// - Iterative algorithms (repeat until stable state)
-// - Mutable data structures (modify in place for efficiency)
-// - Simulation problems (Conway's Game of Life, cellular automata)
-// - Convergence to fixed point (eventually nothing changes)
+//   - Iterative algorithms (repeat until stable state)
+//   - Mutable data structures (modify in place for efficiency)
+//   - Simulation problems (Conway's Game of Life, cellular automata)
+//   - Convergence to fixed point (eventually nothing changes)
 //
 // Complexity Analysis:
-// - Worst case: O(n * iterations) where n is grid size
-// - Each iteration removes at least 1 roll (or terminates)
-// - Maximum iterations = total number of '@' symbols
-// - In practice: converges quickly (logarithmic-like)
+//   - One full scan of the grid: O(n) where n is grid size
+//   - After that each removed roll re-checks only its 8 neighbors (see
+//     removeUntilStable), so the whole cascade is O(n + removed * 8 * 8)
+//   - A full rescan per round would be O(n * iterations), with up to one
+//     iteration per '@' in the worst case
 func Part2(inputPath string) (int, error) {
-	lines, err := FromFile(inputPath)
+	return input.SolveFile(inputPath, Part2FromReader)
+}
+
+// Part2FromReader is Part2 for input read from r
+func Part2FromReader(r io.Reader) (int, error) {
+	return part2(context.Background(), r)
+}
+
+// Part2Context is Part2 that gives up between removal rounds once ctx is done
+func Part2Context(ctx context.Context, inputPath string) (int, error) {
+	return input.SolveFile(inputPath, func(r io.Reader) (int, error) {
+		return part2(ctx, r)
+	})
+}
+
+func part2(ctx context.Context, r io.Reader) (int, error) {
+	lines, err := NewParser(r).ParseAll()
 	if err != nil {
 		return 0, fmt.Errorf("loading input: %w", err)
 	}
 
-	// Convert to mutable grid: [][]byte instead of []string
-	// Why [][]byte?
-	// - Mutable: can modify individual cells (strings are immutable in Go)
-	// - Efficient: avoid creating new strings on each modification
-	// - Idiomatic: byte slices are standard for text manipulation
-	grid := make([][]byte, len(lines))
-	for i, line := range lines {
-		// []byte(line) converts string to byte slice (makes a copy)
-		grid[i] = []byte(line)
-	}
+	grid := toMutableGrid(lines)
+
+	return removeUntilStableContext(ctx, grid)
+}
 
+// removeUntilStable runs removal rounds until no roll is accessible and
+// returns the total number of rolls removed. The grid is left in its
+// stabilized state.
+func removeUntilStable(grid [][]byte) int {
+	removed, _ := removeUntilStableContext(context.Background(), grid)
+	return removed
+}
+
+// removeUntilStableContext is removeUntilStable checking ctx before each
+// round. On cancellation it returns ctx's error and the grid is left partway
+// through the cascade.
+//
+// Worklist Optimization: only the first round scans the whole grid. Removing a
+// roll can only change the neighbor counts of its 8 surrounding cells, so the
+// next round's candidates are exactly the still-present neighbors of this
+// round's removals. Rounds stay simultaneous - a wave is fully removed before
+// any candidate is evaluated - so the result matches iterating Step until it
+// returns 0, but each round costs O(removed rolls) instead of O(cells).
+func removeUntilStableContext(ctx context.Context, grid [][]byte) (int, error) {
 	totalRemoved := 0
 
-	// Infinite loop with explicit termination: common pattern for simulations
-	// Alternative: while(condition) doesn't exist in Go, use for{} + break
-	for {
-		// Find all currently accessible rolls
-		// Important: find ALL first, then remove ALL
-		// If we removed one-by-one, we'd affect the counts mid-iteration
-		accessible := findAccessibleRolls(grid)
-
-		// Termination condition: no more accessible rolls (stable state reached)
-		if len(accessible) == 0 {
-			break
-		}
+	// queued marks rolls already placed in the next wave so a roll next to
+	// several removals is only enqueued once. A queued roll is always removed
+	// in the following round, so marks never need clearing.
+	queued := make([][]bool, len(grid))
+	for row := range grid {
+		queued[row] = make([]bool, len(grid[row]))
+	}
 
-		// Batch removal: remove all accessible rolls simultaneously
-		// This simulates "one step" in the iterative process
-		for _, pos := range accessible {
-			grid[pos.row][pos.col] = '.' // Modify in place
+	wave := findAccessibleRolls(grid)
+	for round := 1; len(wave) > 0; round++ {
+		if err := ctx.Err(); err != nil {
+			return totalRemoved, err
 		}
 
-		// Accumulate total across all iterations
-		totalRemoved += len(accessible)
+		// Remove the whole wave first: accessibility for the next round must
+		// be judged against the grid after every simultaneous removal
+		for _, pos := range wave {
+			grid[pos.row][pos.col] = '.'
+		}
+		totalRemoved += len(wave)
+
+		var next []position
+		for _, pos := range wave {
+			for _, dir := range neighborOffsets {
+				n := position{pos.row + dir[0], pos.col + dir[1]}
+				if n.row < 0 || n.row >= len(grid) || n.col < 0 || n.col >= len(grid[n.row]) {
+					continue
+				}
+				if grid[n.row][n.col] == '@' && !queued[n.row][n.col] && isAccessibleMutable(grid, n.row, n.col) {
+					queued[n.row][n.col] = true
+					next = append(next, n)
+				}
+			}
+		}
+		slog.Debug("day4: removal round", "round", round, "removed", len(wave), "total", totalRemoved, "candidates", len(next))
+		wave = next
 	}
 
 	return totalRemoved, nil
 }
 
+// toMutableGrid converts parsed lines into a mutable grid: [][]byte instead of []string.
+//
+// Why [][]byte?
+// - Mutable: can modify individual cells (strings are immutable in Go)
+// - Efficient: avoid creating new strings on each modification
+// - Idiomatic: byte slices are standard for text manipulation
+func toMutableGrid(lines []string) [][]byte {
+	grid := make([][]byte, len(lines))
+	for i, line := range lines {
+		// []byte(line) converts string to byte slice (makes a copy)
+		grid[i] = []byte(line)
+	}
+	return grid
+}
+
 // position represents a 2D coordinate in the grid.
 //
 // Struct Pattern: Use structs to group related data
@@ -111,16 +178,22 @@ func findAccessibleRolls(grid [][]byte) []position {
 // - Small amount of duplication (< 20 lines) is acceptable in Go
 // - "A little copying is better than a little dependency" - Go proverb
 func isAccessibleMutable(grid [][]byte, row, col int) bool {
-	adjacentCount := 0
+	return countNeighbors(grid, row, col) < accessThreshold
+}
 
-	// Same direction vectors as Part1 - mathematical pattern for neighbors
-	directions := [][2]int{
-		{-1, -1}, {-1, 0}, {-1, 1}, // top row
-		{0, -1}, {0, 1},            // left and right
-		{1, -1}, {1, 0}, {1, 1},    // bottom row
-	}
+// neighborOffsets are the same direction vectors as Part1 - the 8 cells
+// surrounding a position
+var neighborOffsets = [][2]int{
+	{-1, -1}, {-1, 0}, {-1, 1}, // top row
+	{0, -1}, {0, 1}, // left and right
+	{1, -1}, {1, 0}, {1, 1}, // bottom row
+}
+
+// countNeighbors counts the rolls among the 8 cells surrounding (row, col).
+func countNeighbors(grid [][]byte, row, col int) int {
+	adjacentCount := 0
 
-	for _, dir := range directions {
+	for _, dir := range neighborOffsets {
 		newRow := row + dir[0]
 		newCol := col + dir[1]
 
@@ -132,5 +205,5 @@ func isAccessibleMutable(grid [][]byte, row, col int) bool {
 		}
 	}
 
-	return adjacentCount < 4
+	return adjacentCount
 }
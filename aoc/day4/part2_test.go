@@ -0,0 +1,85 @@
+package day4
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// randomGrid generates a rows x cols grid where each cell holds a roll with
+// the given probability
+func randomGrid(rng *rand.Rand, rows, cols int, density float64) []string {
+	lines := make([]string, rows)
+	for row := range lines {
+		line := make([]byte, cols)
+		for col := range line {
+			line[col] = '.'
+			if rng.Float64() < density {
+				line[col] = '@'
+			}
+		}
+		lines[row] = string(line)
+	}
+	return lines
+}
+
+// removeByRescan is the straightforward Part2 loop the worklist replaces:
+// rescan the whole grid with Step every round
+func removeByRescan(grid [][]byte) int {
+	total := 0
+	for {
+		removed := Step(grid, removalRule)
+		if removed == 0 {
+			return total
+		}
+		total += removed
+	}
+}
+
+func TestRemoveUntilStableMatchesRescan(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	for i := range 200 {
+		lines := randomGrid(rng, 1+rng.Intn(25), 1+rng.Intn(25), rng.Float64())
+
+		worklist, rescan := toMutableGrid(lines), toMutableGrid(lines)
+		got, want := removeUntilStable(worklist), removeByRescan(rescan)
+		if got != want {
+			t.Fatalf("grid %d: worklist removed %d, rescan removed %d\n%v", i, got, want, lines)
+		}
+		for row := range worklist {
+			if string(worklist[row]) != string(rescan[row]) {
+				t.Fatalf("grid %d: final grids differ at row %d", i, row)
+			}
+		}
+	}
+}
+
+func TestRemoveUntilStableContextCancelled(t *testing.T) {
+	grid := toMutableGrid(benchmarkGrid())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	removed, err := removeUntilStableContext(ctx, grid)
+	if !errors.Is(err, context.Canceled) || removed != 0 {
+		t.Errorf("expected to stop before the first round, got %d removed, %v", removed, err)
+	}
+}
+
+func benchmarkGrid() []string {
+	return randomGrid(rand.New(rand.NewSource(1)), 500, 500, 0.7)
+}
+
+func BenchmarkRemoveUntilStable(b *testing.B) {
+	lines := benchmarkGrid()
+	for b.Loop() {
+		removeUntilStable(toMutableGrid(lines))
+	}
+}
+
+func BenchmarkRemoveByRescan(b *testing.B) {
+	lines := benchmarkGrid()
+	for b.Loop() {
+		removeByRescan(toMutableGrid(lines))
+	}
+}
@@ -0,0 +1,84 @@
+package day4
+
+import "fmt"
+
+// ShortestForkliftPath returns the fewest orthogonal steps a forklift needs to
+// drive from an empty cell beside the roll at from to an empty cell beside the
+// roll at to. Forklifts only drive over empty '.' cells.
+//
+// Graph Modeling: every empty cell is a node and orthogonally adjacent empty
+// cells share an edge. Breadth-first search explores nodes in order of
+// distance, so the first time a goal cell is dequeued its distance is minimal.
+// All of from's empty neighbors are seeded at distance 0 (multi-source BFS).
+//
+// Returns -1 (with a nil error) when no route exists. Both endpoints must be
+// accessible rolls; anything else is reported as an error.
+func ShortestForkliftPath(inputPath string, from, to position) (int, error) {
+	grid, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	for _, p := range []position{from, to} {
+		if !inBounds(grid, p) || grid[p.row][p.col] != '@' {
+			return 0, fmt.Errorf("(%d,%d) is not a roll", p.row, p.col)
+		}
+		if !isAccessible(grid, p.row, p.col) {
+			return 0, fmt.Errorf("roll at (%d,%d) is not accessible", p.row, p.col)
+		}
+	}
+
+	return forkliftDistance(grid, from, to), nil
+}
+
+// orthogonal lists the four directions a forklift can drive
+var orthogonal = [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+
+// inBounds reports whether p addresses a cell of the (possibly ragged) grid
+func inBounds(grid []string, p position) bool {
+	return p.row >= 0 && p.row < len(grid) && p.col >= 0 && p.col < len(grid[p.row])
+}
+
+// emptyNeighbors returns the empty cells orthogonally adjacent to p
+func emptyNeighbors(grid []string, p position) []position {
+	var neighbors []position
+	for _, dir := range orthogonal {
+		n := position{p.row + dir[0], p.col + dir[1]}
+		if inBounds(grid, n) && grid[n.row][n.col] == '.' {
+			neighbors = append(neighbors, n)
+		}
+	}
+	return neighbors
+}
+
+// forkliftDistance runs the BFS between the empty cells beside two rolls
+func forkliftDistance(grid []string, from, to position) int {
+	goals := make(map[position]bool)
+	for _, p := range emptyNeighbors(grid, to) {
+		goals[p] = true
+	}
+
+	// Slice-backed FIFO queue: append to enqueue, advance head to dequeue
+	dist := make(map[position]int)
+	var queue []position
+	for _, p := range emptyNeighbors(grid, from) {
+		dist[p] = 0
+		queue = append(queue, p)
+	}
+
+	for head := 0; head < len(queue); head++ {
+		current := queue[head]
+		if goals[current] {
+			return dist[current]
+		}
+
+		for _, next := range emptyNeighbors(grid, current) {
+			if _, seen := dist[next]; !seen {
+				dist[next] = dist[current] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return -1
+}
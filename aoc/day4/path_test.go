@@ -0,0 +1,52 @@
+package day4
+
+import "testing"
+
+func TestShortestForkliftPathCorridor(t *testing.T) {
+	// Empty corridor along row 1 links the cells beside (0,0) and (0,6)
+	path := writeGrid(t, "@@@@@@@\n.......\n@@@@@@@")
+
+	steps, err := ShortestForkliftPath(path, position{0, 0}, position{0, 6})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if steps != 6 {
+		t.Errorf("expected 6 steps along the corridor, got %d", steps)
+	}
+}
+
+func TestShortestForkliftPathSharedCell(t *testing.T) {
+	path := writeGrid(t, "@.@")
+
+	steps, err := ShortestForkliftPath(path, position{0, 0}, position{0, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if steps != 0 {
+		t.Errorf("expected 0 steps when both rolls border the same cell, got %d", steps)
+	}
+}
+
+func TestShortestForkliftPathBlocked(t *testing.T) {
+	// A wall of rolls in column 3 separates the two open areas
+	path := writeGrid(t, "@..@..@\n@..@..@\n@..@..@")
+
+	steps, err := ShortestForkliftPath(path, position{0, 0}, position{0, 6})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if steps != -1 {
+		t.Errorf("expected -1 for a blocked route, got %d", steps)
+	}
+}
+
+func TestShortestForkliftPathInvalidEndpoints(t *testing.T) {
+	path := writeGrid(t, "@.@")
+
+	if _, err := ShortestForkliftPath(path, position{0, 1}, position{0, 2}); err == nil {
+		t.Error("expected an error when from is not a roll")
+	}
+	if _, err := ShortestForkliftPath(path, position{0, 0}, position{3, 3}); err == nil {
+		t.Error("expected an error when to is out of bounds")
+	}
+}
@@ -0,0 +1,486 @@
+package day4
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RemovalsToUnlock returns the fewest rolls that must be removed, each one
+// while it is accessible, before the roll at (row, col) becomes accessible.
+//
+// This is a target set selection problem, with no efficient exact algorithm,
+// so it runs a branch-and-bound search over sets of removed rolls. The bound
+// to beat is the part of Part2's cascade the target depends on, trimmed of
+// every roll the rest can do without. The search grows exponentially with the
+// answer: on a puzzle input, answers up to about a dozen take at most seconds,
+// and CascadeRemovalsToUnlock is the instant upper bound for deeper targets.
+//
+// Errors are the same as CascadeRemovalsToUnlock's.
+func RemovalsToUnlock(inputPath string, row, col int) (int, error) {
+	lines, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	return removalsToUnlock(toMutableGrid(lines), row, col)
+}
+
+// removalsToUnlock searches grid, which it leaves untouched, for the fewest
+// removals that make the target accessible.
+func removalsToUnlock(grid [][]byte, row, col int) (int, error) {
+	// the cascade removes everything that can ever go, so it also tells
+	// whether the target can be unlocked at all
+	waves, err := cascadeToUnlock(toMutableGrid(toLines(grid)), row, col)
+	if err != nil || len(waves) == 0 {
+		return 0, err
+	}
+
+	s := unlockSearch{grid: grid, target: position{row, col}, seen: make(map[string]bool)}
+	s.best = len(s.prune(s.needed(waves)))
+	s.search(nil, nil)
+	return s.best, nil
+}
+
+// unlockSearch holds the state of removalsToUnlock's search.
+//
+// A set of rolls can be removed exactly when removing its accessible members
+// until none is left clears it all: accessibility is monotone (removing rolls
+// only lowers neighbor counts), so waiting never makes a roll harder to take.
+// The search grows such a set by taking a roll still stuck (or the target)
+// and committing to which of its neighbors go before it, each choice adding
+// the ones not chosen yet. Once every stuck roll is committed, they are
+// waiting on one another and the branch is dead.
+type unlockSearch struct {
+	grid   [][]byte
+	target position
+	best   int
+	seen   map[string]bool
+}
+
+// settle removes the accessible members of chosen until none is left, and
+// returns what it removed and the positions still stuck: the target, if it is
+// not accessible yet, and the members left in the grid
+func (s *unlockSearch) settle(chosen []position) (map[position]bool, []position) {
+	removed := make(map[position]bool, len(chosen))
+	for progress := true; progress; {
+		progress = false
+		for _, pos := range chosen {
+			if !removed[pos] && s.neighbors(removed, pos) < accessThreshold {
+				removed[pos] = true
+				progress = true
+			}
+		}
+	}
+
+	var stuck []position
+	if s.neighbors(removed, s.target) >= accessThreshold {
+		stuck = append(stuck, s.target)
+	}
+	for _, pos := range chosen {
+		if !removed[pos] {
+			stuck = append(stuck, pos)
+		}
+	}
+	return removed, stuck
+}
+
+// needed keeps the rolls of the cascade's waves that the target's unlocking
+// depends on: its neighbors, and theirs removed in earlier waves. Each still
+// goes in its wave, so they make a working removal set, in wave order.
+func (s *unlockSearch) needed(waves [][]position) []position {
+	wave := map[position]int{s.target: len(waves)}
+	for i, w := range waves {
+		for _, pos := range w {
+			wave[pos] = i
+		}
+	}
+
+	keep := make(map[position]bool)
+	queue := []position{s.target}
+	for len(queue) > 0 {
+		pos := queue[0]
+		queue = queue[1:]
+		for _, n := range s.around(pos) {
+			if i, ok := wave[n]; ok && i < wave[pos] && !keep[n] {
+				keep[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	var set []position
+	for _, w := range waves {
+		for _, pos := range w {
+			if keep[pos] {
+				set = append(set, pos)
+			}
+		}
+	}
+	return set
+}
+
+// prune drops every roll of a working removal set that the rest can do
+// without, leaving a smaller bound for the search to beat
+func (s *unlockSearch) prune(set []position) []position {
+	set = slices.Clone(set)
+	// the last rolls removed are the likeliest to be spare
+	for i := len(set) - 1; i >= 0; i-- {
+		without := slices.Delete(slices.Clone(set), i, i+1)
+		if _, stuck := s.settle(without); len(stuck) == 0 {
+			set = without
+		}
+	}
+	return set
+}
+
+// search tries chosen, then every commitment for one of its stuck rolls
+func (s *unlockSearch) search(chosen, committed []position) {
+	if len(chosen) >= s.best {
+		return
+	}
+	key := setKey(chosen) + "|" + setKey(committed)
+	if s.seen[key] {
+		return
+	}
+	s.seen[key] = true
+
+	removed, stuck := s.settle(chosen)
+	if len(stuck) == 0 {
+		s.best = len(chosen)
+		return
+	}
+
+	// branch on the roll with the fewest ways to commit
+	var pick position
+	var options []position
+	need, fewest := 0, -1
+	var demands []unlockDemand
+	first := len(neighborOffsets)
+	for _, pos := range stuck {
+		// whichever stuck roll frees up first gets no help from the others
+		first = min(first, s.neighbors(removed, pos)-accessThreshold+1)
+		if slices.Contains(committed, pos) {
+			continue
+		}
+		var around, unchosen []position
+		for _, n := range s.around(pos) {
+			if !removed[n] && n != s.target {
+				around = append(around, n)
+				if !slices.Contains(chosen, n) {
+					unchosen = append(unchosen, n)
+				}
+			}
+		}
+		missing := s.neighbors(removed, pos) - accessThreshold + 1
+		if len(around) < missing {
+			return
+		}
+		if short := missing - (len(around) - len(unchosen)); short > 0 {
+			demands = append(demands, unlockDemand{unchosen, short})
+		}
+		if ways := binomial(len(around), missing); fewest < 0 || ways < fewest {
+			pick, options, need, fewest = pos, around, missing, ways
+		}
+	}
+	if fewest < 0 || len(chosen)+max(first, packedDemand(demands)) >= s.best {
+		return
+	}
+
+	// rolls already chosen first: they cost nothing, so good sets come early
+	slices.SortStableFunc(options, func(a, b position) int {
+		return cmp.Compare(boolRank(!slices.Contains(chosen, a)), boolRank(!slices.Contains(chosen, b)))
+	})
+	committed = append(slices.Clip(committed), pick)
+	combinations(options, need, func(before []position) {
+		next := slices.Clip(chosen)
+		for _, pos := range before {
+			if !slices.Contains(chosen, pos) {
+				next = append(next, pos)
+			}
+		}
+		s.search(next, committed)
+	})
+}
+
+// unlockDemand is a stuck roll's need for short more of the unchosen rolls
+// around it
+type unlockDemand struct {
+	from  []position
+	short int
+}
+
+// packedDemand adds up demands drawing on disjoint rolls, the largest first:
+// no roll can serve two of them, so the sum bounds the rolls still to add
+func packedDemand(demands []unlockDemand) int {
+	slices.SortFunc(demands, func(a, b unlockDemand) int { return cmp.Compare(b.short, a.short) })
+	used := make(map[position]bool)
+	total := 0
+	for _, d := range demands {
+		if slices.ContainsFunc(d.from, func(pos position) bool { return used[pos] }) {
+			continue
+		}
+		for _, pos := range d.from {
+			used[pos] = true
+		}
+		total += d.short
+	}
+	return total
+}
+
+// boolRank orders false before true
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// binomial is the number of ways to pick k of n items
+func binomial(n, k int) int {
+	ways := 1
+	for i := range k {
+		ways = ways * (n - i) / (i + 1)
+	}
+	return ways
+}
+
+// combinations calls yield with every k-item subset of items, in order
+func combinations(items []position, k int, yield func([]position)) {
+	picked := make([]position, 0, k)
+	var walk func(from int)
+	walk = func(from int) {
+		if len(picked) == k {
+			yield(picked)
+			return
+		}
+		for i := from; i <= len(items)-(k-len(picked)); i++ {
+			picked = append(picked, items[i])
+			walk(i + 1)
+			picked = picked[:len(picked)-1]
+		}
+	}
+	walk(0)
+}
+
+// neighbors counts the rolls around pos that are still in the grid
+func (s *unlockSearch) neighbors(removed map[position]bool, pos position) int {
+	count := 0
+	for _, n := range s.around(pos) {
+		if !removed[n] {
+			count++
+		}
+	}
+	return count
+}
+
+// around lists the rolls of the original grid next to pos
+func (s *unlockSearch) around(pos position) []position {
+	var rolls []position
+	for _, dir := range neighborOffsets {
+		n := position{pos.row + dir[0], pos.col + dir[1]}
+		if n.row >= 0 && n.row < len(s.grid) && n.col >= 0 && n.col < len(s.grid[n.row]) && s.grid[n.row][n.col] == '@' {
+			rolls = append(rolls, n)
+		}
+	}
+	return rolls
+}
+
+// setKey identifies a set of positions regardless of their order
+func setKey(set []position) string {
+	sorted := slices.Clone(set)
+	slices.SortFunc(sorted, func(a, b position) int {
+		return cmp.Or(cmp.Compare(a.row, b.row), cmp.Compare(a.col, b.col))
+	})
+	var b strings.Builder
+	for _, pos := range sorted {
+		fmt.Fprintf(&b, "%d,%d;", pos.row, pos.col)
+	}
+	return b.String()
+}
+
+// CascadeRemovalsToUnlock counts the rolls Part2's cascade removes before the
+// roll at (row, col) becomes accessible.
+//
+// It replays the cascade one round at a time: each round removes every
+// currently accessible roll, and the loop stops after the first round that
+// leaves the target below the threshold. No round order reaches the target
+// sooner, but every roll of those rounds is counted, needed or not, so the
+// result is an upper bound on RemovalsToUnlock's.
+//
+// Errors are returned when the coordinate is out of bounds, does not hold a
+// roll, or can never be unlocked because the grid stabilizes first.
+func CascadeRemovalsToUnlock(inputPath string, row, col int) (int, error) {
+	lines, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	return cascadeRemovalsToUnlock(toMutableGrid(lines), row, col)
+}
+
+// cascadeRemovalsToUnlock runs removal rounds on grid until the target is accessible.
+func cascadeRemovalsToUnlock(grid [][]byte, row, col int) (int, error) {
+	waves, err := cascadeToUnlock(grid, row, col)
+	removed := 0
+	for _, wave := range waves {
+		removed += len(wave)
+	}
+	return removed, err
+}
+
+// cascadeToUnlock is cascadeRemovalsToUnlock listing the rolls each round
+// removes.
+func cascadeToUnlock(grid [][]byte, row, col int) ([][]position, error) {
+	if row < 0 || row >= len(grid) || col < 0 || col >= len(grid[row]) {
+		return nil, fmt.Errorf("target (%d,%d) is outside the grid", row, col)
+	}
+	if grid[row][col] != '@' {
+		return nil, fmt.Errorf("target (%d,%d) is not a roll", row, col)
+	}
+
+	var waves [][]position
+	for !isAccessibleMutable(grid, row, col) {
+		accessible := findAccessibleRolls(grid)
+		if len(accessible) == 0 {
+			return nil, fmt.Errorf("target (%d,%d) never becomes accessible", row, col)
+		}
+
+		for _, pos := range accessible {
+			grid[pos.row][pos.col] = '.'
+		}
+		waves = append(waves, accessible)
+	}
+
+	return waves, nil
+}
+
+// Part2FinalGrid returns the grid left behind once Part2's removal loop
+// stabilizes, with every removed roll shown as '.'.
+func Part2FinalGrid(inputPath string) ([]string, error) {
+	lines, err := FromFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading input: %w", err)
+	}
+
+	grid := toMutableGrid(lines)
+	removeUntilStable(grid)
+	return toLines(grid), nil
+}
+
+// toLines converts a mutable grid back into immutable rows.
+func toLines(grid [][]byte) []string {
+	lines := make([]string, len(grid))
+	for i, row := range grid {
+		lines[i] = string(row)
+	}
+	return lines
+}
+
+// Part2Snapshots records the grid before the first removal round and after
+// every round until it stabilizes. Consecutive snapshots can be compared with
+// GridDiff to see what each round removed.
+func Part2Snapshots(inputPath string) ([][]string, error) {
+	lines, err := FromFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading input: %w", err)
+	}
+
+	grid := toMutableGrid(lines)
+	snapshots := [][]string{toLines(grid)}
+	for Step(grid, removalRule) > 0 {
+		snapshots = append(snapshots, toLines(grid))
+	}
+	return snapshots, nil
+}
+
+// RemovableThisRound returns how many rolls Part2's first removal round takes
+// off the initial grid, without running the rest of the cascade. With the
+// shared accessThreshold this matches Part1's count.
+func RemovableThisRound(inputPath string) (int, error) {
+	lines, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	return len(findAccessibleRolls(toMutableGrid(lines))), nil
+}
+
+// RollsAfterRounds returns how many rolls remain after at most k removal rounds.
+//
+// This interpolates between the two puzzle parts: k = 0 leaves every roll in
+// place, k = 1 removes exactly what Part1 counts, and any k at or beyond the
+// stabilization round leaves what Part2FinalGrid shows. The loop stops early
+// once a round removes nothing.
+func RollsAfterRounds(inputPath string, k int) (int, error) {
+	if k < 0 {
+		return 0, fmt.Errorf("round count must be non-negative, got %d", k)
+	}
+
+	lines, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	remaining := 0
+	for _, line := range lines {
+		remaining += strings.Count(line, "@")
+	}
+
+	grid := toMutableGrid(lines)
+	for range k {
+		removed := Step(grid, removalRule)
+		if removed == 0 {
+			break
+		}
+		remaining -= removed
+	}
+
+	return remaining, nil
+}
+
+// Part2Score weights Part2's removals by when they happen: every roll scores
+// the number of the round (starting at 1) in which it was removed, so rolls
+// buried deep in a cluster are worth more than the ones peeled off first.
+func Part2Score(inputPath string) (int, error) {
+	lines, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	grid := toMutableGrid(lines)
+	score := 0
+	for round := 1; ; round++ {
+		removed := Step(grid, removalRule)
+		if removed == 0 {
+			break
+		}
+		score += round * removed
+	}
+	return score, nil
+}
+
+// Part2Waves lists the coordinates each removal round takes off the grid, one
+// wave per round, in row-major order within each wave. Replaying the waves on
+// the input reconstructs every intermediate state Part2Snapshots would show.
+func Part2Waves(inputPath string) ([][]position, error) {
+	lines, err := FromFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading input: %w", err)
+	}
+
+	grid := toMutableGrid(lines)
+	var waves [][]position
+	for {
+		// findAccessibleRolls scans row by row, so each wave is already row-major
+		wave := findAccessibleRolls(grid)
+		if len(wave) == 0 {
+			return waves, nil
+		}
+		for _, pos := range wave {
+			grid[pos.row][pos.col] = '.'
+		}
+		waves = append(waves, wave)
+	}
+}
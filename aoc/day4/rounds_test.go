@@ -0,0 +1,300 @@
+package day4
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeGrid writes a test grid to a temporary input file and returns its path.
+func writeGrid(t *testing.T, grid string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(grid), 0o644); err != nil {
+		t.Fatalf("failed to write input: %v", err)
+	}
+	return path
+}
+
+func TestRemovalsToUnlockBuried(t *testing.T) {
+	// The centre roll must lose 5 of its 8 neighbors, and each of those needs
+	// some of its own cleared first: 8 removals in all, well short of the 14
+	// the cascade takes.
+	path := writeGrid(t, "@@@@@\n@@@@@\n@@@@@")
+
+	removed, err := RemovalsToUnlock(path, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != 8 {
+		t.Errorf("expected 8 removals, got %d", removed)
+	}
+}
+
+func TestRemovalsToUnlockAlreadyAccessible(t *testing.T) {
+	path := writeGrid(t, "@@@@@\n@@@@@\n@@@@@")
+
+	removed, err := RemovalsToUnlock(path, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != 0 {
+		t.Errorf("expected 0 removals for a corner roll, got %d", removed)
+	}
+}
+
+func TestRemovalsToUnlockErrors(t *testing.T) {
+	path := writeGrid(t, strings.Repeat("@@@@@\n", 5))
+	if _, err := RemovalsToUnlock(path, 2, 2); err == nil {
+		t.Error("expected an error when the target can never unlock")
+	}
+
+	path = writeGrid(t, "@.@\n@@@")
+	if _, err := RemovalsToUnlock(path, 0, 1); err == nil {
+		t.Error("expected an error when the target is not a roll")
+	}
+}
+
+func TestCascadeRemovalsToUnlockBuried(t *testing.T) {
+	// The centre roll is only exposed once four rounds peel the block away:
+	// corners (4), row ends (2), outer diagonals (4), then its last neighbors (4).
+	// Every roll those rounds take counts, needed for the centre or not.
+	path := writeGrid(t, "@@@@@\n@@@@@\n@@@@@")
+
+	removed, err := CascadeRemovalsToUnlock(path, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != 14 {
+		t.Errorf("expected 14 cascade removals, got %d", removed)
+	}
+}
+
+func TestCascadeRemovalsToUnlockAlreadyAccessible(t *testing.T) {
+	path := writeGrid(t, "@@@@@\n@@@@@\n@@@@@")
+
+	removed, err := CascadeRemovalsToUnlock(path, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != 0 {
+		t.Errorf("expected 0 removals for a corner roll, got %d", removed)
+	}
+}
+
+func TestCascadeRemovalsToUnlockErrors(t *testing.T) {
+	path := writeGrid(t, strings.Repeat("@@@@@\n", 5))
+
+	if _, err := CascadeRemovalsToUnlock(path, 2, 2); err == nil {
+		t.Error("expected an error when the grid stabilizes before the target unlocks")
+	}
+
+	path = writeGrid(t, "@.@\n@@@")
+	if _, err := CascadeRemovalsToUnlock(path, 0, 1); err == nil {
+		t.Error("expected an error when the target is not a roll")
+	}
+	if _, err := CascadeRemovalsToUnlock(path, 5, 5); err == nil {
+		t.Error("expected an error when the target is out of bounds")
+	}
+}
+
+func TestPart2FinalGrid(t *testing.T) {
+	// Scattered rolls around the edge go in round 1, as do the block's corners;
+	// the rest of the block always keeps 4+ neighbors and survives.
+	path := writeGrid(t, strings.Join([]string{
+		"@.......@",
+		".........",
+		"..@@@@@..",
+		"..@@@@@..",
+		"..@@@@@..",
+		"..@@@@@..",
+		"..@@@@@..",
+		".........",
+		"@.......@",
+	}, "\n"))
+
+	grid, err := Part2FinalGrid(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		".........",
+		".........",
+		"...@@@...",
+		"..@@@@@..",
+		"..@@@@@..",
+		"..@@@@@..",
+		"...@@@...",
+		".........",
+		".........",
+	}
+	if len(grid) != len(expected) {
+		t.Fatalf("expected %d rows, got %d", len(expected), len(grid))
+	}
+	for i := range expected {
+		if grid[i] != expected[i] {
+			t.Errorf("row %d: expected %q, got %q", i, expected[i], grid[i])
+		}
+	}
+}
+
+func TestRollsAfterRounds(t *testing.T) {
+	// Same block as TestCascadeRemovalsToUnlockBuried: rounds remove 4, 2, 4, 4, then the last roll
+	path := writeGrid(t, "@@@@@\n@@@@@\n@@@@@")
+
+	tests := []struct {
+		k, want int
+	}{
+		{0, 15},
+		{1, 11},
+		{2, 9},
+		{5, 0},
+		{100, 0},
+	}
+	for _, tt := range tests {
+		got, err := RollsAfterRounds(path, tt.k)
+		if err != nil {
+			t.Fatalf("k=%d: unexpected error: %v", tt.k, err)
+		}
+		if got != tt.want {
+			t.Errorf("k=%d: expected %d rolls, got %d", tt.k, tt.want, got)
+		}
+	}
+
+	if _, err := RollsAfterRounds(path, -1); err == nil {
+		t.Error("expected an error for negative k")
+	}
+}
+
+func TestRollsAfterRoundsStableMatchesPart2(t *testing.T) {
+	lines := []string{"..@@.@@@@.", "@@@.@.@.@@", "@@@@@.@.@@", "@.@@@@..@.", "@@.@@@@.@@",
+		".@@@@@@@.@", ".@.@.@.@@@", "@.@@@.@@@@", ".@@@@@@@@.", "@.@.@@@.@."}
+	path := writeGrid(t, strings.Join(lines, "\n"))
+
+	removed, err := Part2(path)
+	if err != nil {
+		t.Fatalf("Part2: %v", err)
+	}
+	initial, err := RollsAfterRounds(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	final, err := RollsAfterRounds(path, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if initial-final != removed {
+		t.Errorf("expected %d removed rolls, got %d", removed, initial-final)
+	}
+}
+
+func TestPart2Score(t *testing.T) {
+	// The four corners (3 neighbors) go in round 1; the two middle rolls start
+	// with 5 neighbors and are only exposed in round 2.
+	path := writeGrid(t, "@@@\n@@@")
+
+	score, err := Part2Score(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 4*1 + 2*2; score != want {
+		t.Errorf("expected score %d, got %d", want, score)
+	}
+
+	total, err := Part2(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 6 {
+		t.Errorf("expected Part2 to remove all 6 rolls, got %d", total)
+	}
+}
+
+func TestRemovableThisRound(t *testing.T) {
+	path := writeGrid(t, "..@@.@@@@.\n@@@.@.@.@@\n@@@@@.@.@@\n@.@@@@..@.\n@@.@@@@.@@\n.@@@@@@@.@\n.@.@.@.@@@\n@.@@@.@@@@\n.@@@@@@@@.\n@.@.@@@.@.")
+
+	removable, err := RemovableThisRound(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Count what Step actually removes in the first generation
+	lines, err := FromFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if round1 := Step(toMutableGrid(lines), removalRule); removable != round1 {
+		t.Errorf("expected %d removable rolls (round 1), got %d", round1, removable)
+	}
+
+	part1, err := Part1(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removable != part1 || removable != 13 {
+		t.Errorf("expected 13 removable rolls matching Part1 (%d), got %d", part1, removable)
+	}
+}
+
+func TestPart2Waves(t *testing.T) {
+	path := writeGrid(t, "..@@.@@@@.\n@@@.@.@.@@\n@@@@@.@.@@\n@.@@@@..@.\n@@.@@@@.@@\n.@@@@@@@.@\n.@.@.@.@@@\n@.@@@.@@@@\n.@@@@@@@@.\n@.@.@@@.@.")
+
+	waves, err := Part2Waves(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rowMajor := func(a, b position) int {
+		if a.row != b.row {
+			return a.row - b.row
+		}
+		return a.col - b.col
+	}
+
+	removed := make(map[position]bool)
+	for i, wave := range waves {
+		if !slices.IsSortedFunc(wave, rowMajor) {
+			t.Errorf("wave %d is not in row-major order: %v", i+1, wave)
+		}
+		for _, pos := range wave {
+			if removed[pos] {
+				t.Errorf("wave %d removes %v a second time", i+1, pos)
+			}
+			removed[pos] = true
+		}
+	}
+	if len(waves[0]) != 13 {
+		t.Errorf("expected 13 rolls in the first wave, got %d", len(waves[0]))
+	}
+
+	// The union must be exactly the rolls that are gone once Part2 stabilizes
+	initial, err := FromFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	final, err := Part2FinalGrid(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	eventually := make(map[position]bool)
+	for row := range initial {
+		for col := range initial[row] {
+			if initial[row][col] == '@' && final[row][col] == '.' {
+				eventually[position{row, col}] = true
+			}
+		}
+	}
+	if len(removed) != len(eventually) {
+		t.Errorf("waves remove %d rolls, expected %d", len(removed), len(eventually))
+	}
+	for pos := range eventually {
+		if !removed[pos] {
+			t.Errorf("roll %v is removed by Part2 but missing from the waves", pos)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package day4
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Part1Exposed counts rolls that have at least one orthogonally exposed side.
+//
+// Alternative Reachability Model: instead of counting crowded neighbors, a
+// forklift can reach a roll whenever it can approach from an open side. Only
+// the four orthogonal directions matter here - diagonals don't give a forklift
+// room to pull in.
+func Part1Exposed(inputPath string) (int, error) {
+	grid, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	count := 0
+	for row := 0; row < len(grid); row++ {
+		for col := 0; col < len(grid[row]); col++ {
+			if grid[row][col] == '@' && isExposed(grid, row, col) {
+				count++
+			}
+		}
+	}
+
+	return count, nil
+}
+
+// isExposed returns true if any orthogonal neighbor of (row, col) is empty or
+// lies outside the grid (the boundary counts as open floor).
+func isExposed(grid []string, row, col int) bool {
+	for _, dir := range orthogonal {
+		newRow := row + dir[0]
+		newCol := col + dir[1]
+
+		// Out of bounds (including past the end of a ragged row) is open
+		if newRow < 0 || newRow >= len(grid) ||
+			newCol < 0 || newCol >= len(grid[newRow]) ||
+			grid[newRow][newCol] != '@' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Part2SequentialPriority removes accessible rolls one at a time instead of in
+// rounds: after every single removal accessibility is recomputed, and the next
+// roll taken is the accessible one that sorts first under less.
+//
+// Because removing a roll only lowers its neighbors' counts, every roll Part2
+// removes stays removable until it is taken, so the total always matches Part2;
+// the priority only changes the order (and therefore which rolls open up when).
+func Part2SequentialPriority(inputPath string, less func(a, b position) bool) (int, error) {
+	lines, err := FromFile(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: %w", err)
+	}
+
+	return len(removeByPriority(toMutableGrid(lines), less)), nil
+}
+
+// removeByPriority empties grid one highest-priority accessible roll at a time
+// and returns the removal order
+func removeByPriority(grid [][]byte, less func(a, b position) bool) []position {
+	cmp := func(a, b position) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	}
+
+	var order []position
+	for {
+		accessible := findAccessibleRolls(grid)
+		if len(accessible) == 0 {
+			return order
+		}
+
+		next := slices.MinFunc(accessible, cmp)
+		grid[next.row][next.col] = '.'
+		order = append(order, next)
+	}
+}
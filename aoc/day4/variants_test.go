@@ -0,0 +1,73 @@
+package day4
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIsExposed(t *testing.T) {
+	grid := []string{
+		"@@@",
+		"@@@",
+		"@@.",
+	}
+
+	if isExposed(grid, 1, 1) {
+		t.Error("centre roll is surrounded orthogonally and should not be exposed")
+	}
+	if !isExposed(grid, 0, 1) {
+		t.Error("roll touching the top edge should be exposed")
+	}
+	if !isExposed(grid, 1, 2) {
+		t.Error("roll above an empty cell should be exposed")
+	}
+}
+
+func TestPart1Exposed(t *testing.T) {
+	path := writeGrid(t, "@@@\n@@@\n@@@")
+
+	count, err := Part1Exposed(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 8 {
+		t.Errorf("expected 8 exposed rolls (all but the centre), got %d", count)
+	}
+}
+
+func TestPart2SequentialPriority(t *testing.T) {
+	const grid = "..@@.@@@@.\n@@@.@.@.@@\n@@@@@.@.@@\n@.@@@@..@.\n@@.@@@@.@@\n.@@@@@@@.@\n.@.@.@.@@@\n@.@@@.@@@@\n.@@@@@@@@.\n@.@.@@@.@."
+	path := writeGrid(t, grid)
+
+	rowMajor := func(a, b position) bool {
+		return a.row < b.row || (a.row == b.row && a.col < b.col)
+	}
+	bottomRightFirst := func(a, b position) bool { return rowMajor(b, a) }
+
+	for name, less := range map[string]func(a, b position) bool{"row-major": rowMajor, "bottom-right first": bottomRightFirst} {
+		total, err := Part2SequentialPriority(path, less)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if total != 43 {
+			t.Errorf("%s: expected the Part2 total 43, got %d", name, total)
+		}
+	}
+
+	lines, err := FromFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	forward := removeByPriority(toMutableGrid(lines), rowMajor)
+	backward := removeByPriority(toMutableGrid(lines), bottomRightFirst)
+
+	if forward[0] != (position{0, 2}) {
+		t.Errorf("row-major should start at the first accessible roll (0,2), got %v", forward[0])
+	}
+	if backward[0].row != 9 {
+		t.Errorf("bottom-right first should start on the last row, got %v", backward[0])
+	}
+	if slices.Equal(forward, backward) {
+		t.Error("different priorities should produce different removal orders")
+	}
+}
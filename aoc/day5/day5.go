@@ -1,11 +1,17 @@
 package day5
 
+import (
+	"io"
+
+	"adv2025/aoc/registry"
+)
+
 // Parts contains all implemented parts for this day.
 //
 // This slice demonstrates Go's first-class function support - functions can be
-// stored in slices, passed as parameters, and invoked dynamically. The main
-// runner uses variadic arguments to register all parts at once:
-//   register(5, day5.Parts...)
+// stored in slices, passed as parameters, and invoked dynamically. The package's
+// init walks the slice and registers every part with aoc/registry, so the
+// main runner picks them up without listing days by hand.
 //
 // Benefits of this pattern:
 // - Each day package is self-describing (knows its own parts)
@@ -13,3 +19,14 @@ package day5
 // - Dynamic registration without reflection
 // - Type-safe: compiler ensures all functions match the signature
 var Parts = []func(string) (int, error){Part1, Part2}
+
+// ReaderParts holds the same parts as Parts, reading input from an io.Reader
+var ReaderParts = []func(io.Reader) (int, error){Part1FromReader, Part2FromReader}
+
+// init registers every part with the runner
+func init() {
+	for i, part := range Parts {
+		registry.Register(5, i+1, part)
+		registry.RegisterReader(5, i+1, ReaderParts[i])
+	}
+}
@@ -1,14 +1,26 @@
 package day5
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"adv2025/aoc/input"
+)
 
 // Part1 solves Day 5 Part 1: Count how many available ingredient IDs are fresh.
 // An ingredient ID is fresh if it falls within any of the fresh ranges (inclusive).
 func Part1(inputPath string) (int, error) {
-	db, err := FromFile(inputPath)
+	return input.SolveFile(inputPath, Part1FromReader)
+}
+
+// Part1FromReader is Part1 for input read from r
+func Part1FromReader(r io.Reader) (int, error) {
+	db, err := NewParser(r).Parse()
 	if err != nil {
 		return 0, fmt.Errorf("loading input: %w", err)
 	}
+	slog.Debug("day5: database parsed", "part", 1, "ranges", len(db.FreshRanges), "ids", len(db.AvailableIDs))
 
 	freshCount := 0
 	for _, id := range db.AvailableIDs {
@@ -17,6 +29,7 @@ func Part1(inputPath string) (int, error) {
 		}
 	}
 
+	slog.Debug("day5: ids checked", "fresh", freshCount, "spoiled", len(db.AvailableIDs)-freshCount)
 	return freshCount, nil
 }
 
@@ -2,18 +2,29 @@ package day5
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
 	"sort"
+
+	"adv2025/aoc/input"
 )
 
 // Part2 solves Day 5 Part 2: Count total unique ingredient IDs covered by all fresh ranges.
 // We need to merge overlapping ranges and sum their sizes.
 func Part2(inputPath string) (int, error) {
-	db, err := FromFile(inputPath)
+	return input.SolveFile(inputPath, Part2FromReader)
+}
+
+// Part2FromReader is Part2 for input read from r
+func Part2FromReader(r io.Reader) (int, error) {
+	db, err := NewParser(r).Parse()
 	if err != nil {
 		return 0, fmt.Errorf("loading input: %w", err)
 	}
+	slog.Debug("day5: database parsed", "part", 2, "ranges", len(db.FreshRanges), "ids", len(db.AvailableIDs))
 
 	merged := mergeRanges(db.FreshRanges)
+	slog.Debug("day5: ranges merged", "ranges", len(db.FreshRanges), "merged", len(merged))
 
 	totalCount := 0
 	for _, r := range merged {
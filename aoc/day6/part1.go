@@ -1,13 +1,25 @@
 package day6
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"adv2025/aoc/input"
+)
 
 // Part1 solves Day 6 Part 1 (left-to-right field reading)
 func Part1(inputPath string) (int, error) {
-	lines, err := FromFile(inputPath)
+	return input.SolveFile(inputPath, Part1FromReader)
+}
+
+// Part1FromReader is Part1 for input read from r
+func Part1FromReader(r io.Reader) (int, error) {
+	lines, err := NewParser(r).ParseAll()
 	if err != nil {
 		return 0, fmt.Errorf("loading input: %w", err)
 	}
+	slog.Debug("day6: worksheet parsed", "part", 1, "lines", len(lines))
 
 	return SolveWorksheet(lines, LeftToRight)
 }
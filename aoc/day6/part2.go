@@ -1,13 +1,25 @@
 package day6
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"adv2025/aoc/input"
+)
 
 // Part2 solves Day 6 Part 2 (right-to-left column reading)
 func Part2(inputPath string) (int, error) {
-	lines, err := FromFile(inputPath)
+	return input.SolveFile(inputPath, Part2FromReader)
+}
+
+// Part2FromReader is Part2 for input read from r
+func Part2FromReader(r io.Reader) (int, error) {
+	lines, err := NewParser(r).ParseAll()
 	if err != nil {
 		return 0, fmt.Errorf("loading input: %w", err)
 	}
+	slog.Debug("day6: worksheet parsed", "part", 2, "lines", len(lines))
 
 	return SolveWorksheet(lines, RightToLeft)
 }
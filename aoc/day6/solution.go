@@ -2,6 +2,7 @@ package day6
 
 import (
 	"fmt"
+	"log/slog"
 	"strconv"
 	"strings"
 )
@@ -180,6 +181,7 @@ func SolveWorksheet(lines []string, mode ReadingMode) (int, error) {
 	if err != nil {
 		return 0, fmt.Errorf("parsing problems: %w", err)
 	}
+	slog.Debug("day6: problems parsed", "problems", len(problems))
 
 	grandTotal := 0
 	for _, problem := range problems {
@@ -0,0 +1,158 @@
+// Package fetch talks to adventofcode.com: it downloads puzzle inputs and
+// submits answers.
+//
+// Inputs are personal to each account, so every request carries the user's
+// session cookie. EnsureInput caches downloads on disk: once a day's input is
+// saved the server is never asked for it again. Requests are also spaced at
+// least minInterval apart, so fetching a whole month stays polite.
+package fetch
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// baseURL is the event's root; tests point it at a local server
+var baseURL = "https://adventofcode.com/2025"
+
+// userAgent identifies the runner, as the site asks automated tools to do
+const userAgent = "github.com/gman622/adv2025 input fetcher"
+
+var client = &http.Client{Timeout: 30 * time.Second}
+
+// minInterval is the least time between two requests to the site
+var minInterval = 3 * time.Second
+
+var (
+	throttleMu  sync.Mutex
+	lastRequest time.Time
+)
+
+// throttle blocks until minInterval has passed since the previous request
+func throttle() {
+	throttleMu.Lock()
+	defer throttleMu.Unlock()
+
+	if wait := time.Until(lastRequest.Add(minInterval)); wait > 0 {
+		time.Sleep(wait)
+	}
+	lastRequest = time.Now()
+}
+
+var (
+	// ErrBadSession means the server rejected the session cookie (HTTP 400)
+	ErrBadSession = errors.New("session cookie rejected; it may be missing or expired")
+
+	// ErrNotReleased means the day's input does not exist yet (HTTP 404)
+	ErrNotReleased = errors.New("puzzle input not available; the day may not be released yet")
+)
+
+// do sends an authenticated, throttled request for path under baseURL; an
+// empty session sends no cookie. A non-nil form is sent as a urlencoded POST
+// body.
+func do(method, path string, form url.Values, session string) (*http.Response, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequest(method, baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	if session != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: session})
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	throttle()
+	return client.Do(req)
+}
+
+// FetchInput downloads the input for day using the given session cookie.
+// HTTP 400 and 404 responses are reported as ErrBadSession and ErrNotReleased.
+func FetchInput(day int, session string) ([]byte, error) {
+	if session == "" {
+		return nil, ErrBadSession
+	}
+
+	resp, err := do(http.MethodGet, fmt.Sprintf("/day/%d/input", day), nil, session)
+	if err != nil {
+		return nil, fmt.Errorf("fetching day %d: %w", day, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest:
+		return nil, fmt.Errorf("day %d: %w", day, ErrBadSession)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("day %d: %w", day, ErrNotReleased)
+	default:
+		return nil, fmt.Errorf("day %d: unexpected HTTP status %s", day, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading day %d: %w", day, err)
+	}
+	return body, nil
+}
+
+// CheckSession asks for day 1's input, the cheapest request that needs a
+// valid cookie, and reports ErrBadSession if the site rejects session
+func CheckSession(session string) error {
+	_, err := FetchInput(1, session)
+	return err
+}
+
+// EnsureInput makes sure path holds the input for day, downloading it only
+// when the file does not exist yet. The file is written atomically, so a failed
+// or interrupted download never leaves a partial input (or an error page) behind.
+func EnsureInput(path string, day int, session string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	data, err := FetchInput(day, session)
+	if err != nil {
+		return err
+	}
+
+	if err := writeAtomic(path, data); err != nil {
+		return fmt.Errorf("caching input: %w", err)
+	}
+	return nil
+}
+
+// writeAtomic replaces path with data through a temporary file in the same
+// directory, so readers never see a partly written file
+func writeAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,121 @@
+package fetch
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// serve points the package at a fake site that answers day 1 for the "good"
+// session and counts every request it receives
+func serve(t *testing.T) *int {
+	t.Helper()
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		cookie, err := r.Cookie("session")
+		switch {
+		case err != nil || cookie.Value != "good":
+			http.Error(w, "<html>Puzzle inputs differ by user.</html>", http.StatusBadRequest)
+		case r.URL.Path != "/day/1/input":
+			http.NotFound(w, r)
+		default:
+			w.Write([]byte("L68\nL30\n"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	old, oldInterval := baseURL, minInterval
+	baseURL, minInterval = srv.URL, 0
+	t.Cleanup(func() { baseURL, minInterval = old, oldInterval })
+	return &requests
+}
+
+func TestFetchInput(t *testing.T) {
+	serve(t)
+
+	data, err := FetchInput(1, "good")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "L68\nL30\n" {
+		t.Errorf("unexpected body %q", data)
+	}
+}
+
+func TestFetchInputErrors(t *testing.T) {
+	serve(t)
+
+	if _, err := FetchInput(1, "expired"); !errors.Is(err, ErrBadSession) {
+		t.Errorf("expected ErrBadSession for a rejected cookie, got %v", err)
+	}
+	if _, err := FetchInput(25, "good"); !errors.Is(err, ErrNotReleased) {
+		t.Errorf("expected ErrNotReleased for a missing day, got %v", err)
+	}
+}
+
+func TestCheckSession(t *testing.T) {
+	serve(t)
+
+	if err := CheckSession("good"); err != nil {
+		t.Errorf("expected the good session to be accepted, got %v", err)
+	}
+	for _, session := range []string{"expired", ""} {
+		if err := CheckSession(session); !errors.Is(err, ErrBadSession) {
+			t.Errorf("CheckSession(%q): expected ErrBadSession, got %v", session, err)
+		}
+	}
+}
+
+func TestEnsureInputCaches(t *testing.T) {
+	requests := serve(t)
+	path := filepath.Join(t.TempDir(), "inputs", "day1_input.txt")
+
+	for range 3 {
+		if err := EnsureInput(path, 1, "good"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if *requests != 1 {
+		t.Errorf("expected a single download, got %d requests", *requests)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("input was not cached: %v", err)
+	}
+	if string(data) != "L68\nL30\n" {
+		t.Errorf("unexpected cached input %q", data)
+	}
+}
+
+func TestEnsureInputLeavesNoFileOnError(t *testing.T) {
+	serve(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "day1_input.txt")
+
+	if err := EnsureInput(path, 1, "expired"); !errors.Is(err, ErrBadSession) {
+		t.Fatalf("expected ErrBadSession, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected nothing written on error, found %d entries", len(entries))
+	}
+}
+
+func TestThrottleSpacesRequests(t *testing.T) {
+	old := minInterval
+	minInterval = 20 * time.Millisecond
+	t.Cleanup(func() { minInterval = old })
+
+	throttle()
+	start := time.Now()
+	throttle()
+	if elapsed := time.Since(start); elapsed < minInterval {
+		t.Errorf("expected the second request to wait %v, waited %v", minInterval, elapsed)
+	}
+}
@@ -0,0 +1,149 @@
+package fetch
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// LeaderboardMaxAge is how long a downloaded leaderboard is reused: the site
+// asks tools to fetch a private leaderboard at most once every 15 minutes
+const LeaderboardMaxAge = 15 * time.Minute
+
+// ErrNoLeaderboard means the leaderboard does not exist or the session's
+// account is not a member of it
+var ErrNoLeaderboard = errors.New("leaderboard not found, or the session is not a member")
+
+// ErrBadLeaderboardID means an ID is not the positive number from a
+// leaderboard's URL; it is refused before it reaches a URL or a file name
+var ErrBadLeaderboardID = errors.New("leaderboard ID must be a positive number")
+
+// CheckLeaderboardID returns ErrBadLeaderboardID unless id is a positive
+// decimal number, so it cannot escape a URL path or the cache directory
+func CheckLeaderboardID(id string) error {
+	if n, err := strconv.Atoi(id); err != nil || n <= 0 || strconv.Itoa(n) != id {
+		return fmt.Errorf("%q: %w", id, ErrBadLeaderboardID)
+	}
+	return nil
+}
+
+// Leaderboard is a private leaderboard as the site's JSON API returns it
+type Leaderboard struct {
+	Event   string            `json:"event"`
+	OwnerID int               `json:"owner_id"`
+	Members map[string]Member `json:"members"`
+}
+
+// Member is one participant of a private leaderboard
+type Member struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"` // empty for anonymous users
+	Stars      int    `json:"stars"`
+	LocalScore int    `json:"local_score"`
+	LastStarTS int64  `json:"last_star_ts"`
+
+	// CompletionDayLevel maps day, then part, to when the star was earned
+	CompletionDayLevel map[string]map[string]struct {
+		GetStarTS int64 `json:"get_star_ts"`
+	} `json:"completion_day_level"`
+}
+
+// DisplayName is the member's name, or the site's label for anonymous users
+func (m Member) DisplayName() string {
+	if m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+	return m.Name
+}
+
+// StarsOn returns how many stars (0-2) the member has for day
+func (m Member) StarsOn(day int) int {
+	return len(m.CompletionDayLevel[strconv.Itoa(day)])
+}
+
+// Ranked lists the members by local score, then stars, then whoever got
+// their last star first, as the site orders them
+func (l *Leaderboard) Ranked() []Member {
+	members := make([]Member, 0, len(l.Members))
+	for _, m := range l.Members {
+		members = append(members, m)
+	}
+	slices.SortFunc(members, func(a, b Member) int {
+		return cmp.Or(
+			cmp.Compare(b.LocalScore, a.LocalScore),
+			cmp.Compare(b.Stars, a.Stars),
+			cmp.Compare(a.LastStarTS, b.LastStarTS),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+	return members
+}
+
+// FetchLeaderboard downloads the raw JSON of private leaderboard id
+func FetchLeaderboard(id, session string) ([]byte, error) {
+	if err := CheckLeaderboardID(id); err != nil {
+		return nil, err
+	}
+	if session == "" {
+		return nil, ErrBadSession
+	}
+
+	resp, err := do(http.MethodGet, "/leaderboard/private/view/"+id+".json", nil, session)
+	if err != nil {
+		return nil, fmt.Errorf("fetching leaderboard %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest:
+		return nil, fmt.Errorf("leaderboard %s: %w", id, ErrBadSession)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("leaderboard %s: %w", id, ErrNoLeaderboard)
+	default:
+		return nil, fmt.Errorf("leaderboard %s: unexpected HTTP status %s", id, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading leaderboard %s: %w", id, err)
+	}
+	// a stale session is redirected to an HTML page rather than refused
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("leaderboard %s: %w", id, ErrBadSession)
+	}
+	return body, nil
+}
+
+// EnsureLeaderboard returns leaderboard id, reusing the copy cached at path
+// while it is younger than LeaderboardMaxAge and downloading it otherwise.
+// Downloads go through the same throttle as inputs and are cached
+// atomically, like EnsureInput.
+func EnsureLeaderboard(path, id, session string) (*Leaderboard, error) {
+	var data []byte
+	info, err := os.Stat(path)
+	if err == nil && time.Since(info.ModTime()) < LeaderboardMaxAge {
+		data, err = os.ReadFile(path)
+	}
+	if data == nil || err != nil {
+		if data, err = FetchLeaderboard(id, session); err != nil {
+			return nil, err
+		}
+		if err := writeAtomic(path, data); err != nil {
+			return nil, fmt.Errorf("caching leaderboard: %w", err)
+		}
+	}
+
+	var board Leaderboard
+	if err := json.Unmarshal(data, &board); err != nil {
+		return nil, fmt.Errorf("parsing leaderboard %s: %w", id, err)
+	}
+	return &board, nil
+}
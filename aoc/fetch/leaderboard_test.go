@@ -0,0 +1,117 @@
+package fetch
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+const sampleLeaderboard = `{"event":"2025","owner_id":1,"members":{
+	"1":{"id":1,"name":"ada","stars":3,"local_score":10,"last_star_ts":200,
+	     "completion_day_level":{"1":{"1":{"get_star_ts":100},"2":{"get_star_ts":150}},"2":{"1":{"get_star_ts":200}}}},
+	"2":{"id":2,"name":null,"stars":3,"local_score":10,"last_star_ts":100,"completion_day_level":{}},
+	"3":{"id":3,"name":"bob","stars":1,"local_score":12,"last_star_ts":50,"completion_day_level":{}}}}`
+
+// serveLeaderboard points the package at a fake site holding leaderboard 1
+func serveLeaderboard(t *testing.T) *int {
+	t.Helper()
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch cookie, err := r.Cookie("session"); {
+		case err != nil || cookie.Value != "good":
+			w.Write([]byte("<html>log in</html>")) // the site redirects to a login page
+		case r.URL.Path != "/leaderboard/private/view/1.json":
+			http.NotFound(w, r)
+		default:
+			w.Write([]byte(sampleLeaderboard))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	old, oldInterval := baseURL, minInterval
+	baseURL, minInterval = srv.URL, 0
+	t.Cleanup(func() { baseURL, minInterval = old, oldInterval })
+	return &requests
+}
+
+func TestEnsureLeaderboardCaches(t *testing.T) {
+	requests := serveLeaderboard(t)
+	path := filepath.Join(t.TempDir(), "leaderboard-1.json")
+
+	for range 2 {
+		board, err := EnsureLeaderboard(path, "1", "good")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(board.Members) != 3 {
+			t.Fatalf("expected 3 members, got %d", len(board.Members))
+		}
+	}
+	if *requests != 1 {
+		t.Errorf("expected the second call to use the cache, got %d requests", *requests)
+	}
+
+	stale := time.Now().Add(-LeaderboardMaxAge)
+	if err := os.Chtimes(path, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := EnsureLeaderboard(path, "1", "good"); err != nil || *requests != 2 {
+		t.Errorf("expected a stale cache to be refreshed, got %d requests, %v", *requests, err)
+	}
+}
+
+func TestFetchLeaderboardErrors(t *testing.T) {
+	serveLeaderboard(t)
+
+	if _, err := FetchLeaderboard("1", "expired"); !errors.Is(err, ErrBadSession) {
+		t.Errorf("expected ErrBadSession for a login page, got %v", err)
+	}
+	if _, err := FetchLeaderboard("2", "good"); !errors.Is(err, ErrNoLeaderboard) {
+		t.Errorf("expected ErrNoLeaderboard, got %v", err)
+	}
+}
+
+func TestFetchLeaderboardRejectsBadIDs(t *testing.T) {
+	requests := serveLeaderboard(t)
+
+	for _, id := range []string{"../x", "1/../../2", "", "0", "-3", "+1", "12a"} {
+		if _, err := FetchLeaderboard(id, "good"); !errors.Is(err, ErrBadLeaderboardID) {
+			t.Errorf("FetchLeaderboard(%q): expected ErrBadLeaderboardID, got %v", id, err)
+		}
+	}
+	if *requests != 0 {
+		t.Errorf("a bad ID should never reach the site, got %d requests", *requests)
+	}
+	if err := CheckLeaderboardID("123456"); err != nil {
+		t.Errorf("unexpected error for a valid ID: %v", err)
+	}
+}
+
+func TestLeaderboardRanked(t *testing.T) {
+	serveLeaderboard(t)
+	board, err := EnsureLeaderboard(filepath.Join(t.TempDir(), "lb.json"), "1", "good")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ranked := board.Ranked()
+	var names []string
+	for _, m := range ranked {
+		names = append(names, m.DisplayName())
+	}
+	// bob leads on score; the tie on score and stars goes to the earlier last star
+	want := []string{"bob", "(anonymous user #2)", "ada"}
+	if !slices.Equal(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+	if ada := ranked[2]; ada.StarsOn(1) != 2 || ada.StarsOn(2) != 1 || ada.StarsOn(3) != 0 {
+		t.Errorf("unexpected stars per day for ada: %v", ada.CompletionDayLevel)
+	}
+}
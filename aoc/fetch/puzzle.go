@@ -0,0 +1,186 @@
+package fetch
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// puzzleRefreshAge is how often a cached statement still missing part 2 is
+// downloaded again, in case part 1 has been solved since
+const puzzleRefreshAge = 15 * time.Minute
+
+// partTwoHeading marks the part 2 statement, shown once part 1 is solved
+const partTwoHeading = "--- Part Two ---"
+
+// FetchPuzzle downloads the HTML page of day's puzzle. Part 1 is public; the
+// session (which may be empty) adds part 2 once part 1 is solved.
+func FetchPuzzle(day int, session string) ([]byte, error) {
+	resp, err := do(http.MethodGet, fmt.Sprintf("/day/%d", day), nil, session)
+	if err != nil {
+		return nil, fmt.Errorf("fetching day %d puzzle: %w", day, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("day %d: %w", day, ErrNotReleased)
+	default:
+		return nil, fmt.Errorf("day %d puzzle: unexpected HTTP status %s", day, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading day %d puzzle: %w", day, err)
+	}
+	return body, nil
+}
+
+// EnsurePuzzle returns day's statement as Markdown, caching it at path. A
+// cached copy with both parts is final; one with only part 1 is downloaded
+// again once it is puzzleRefreshAge old, or right away with refresh.
+func EnsurePuzzle(path string, day int, session string, refresh bool) (string, error) {
+	if info, err := os.Stat(path); err == nil && !refresh {
+		cached, err := os.ReadFile(path)
+		if err == nil && (strings.Contains(string(cached), partTwoHeading) || time.Since(info.ModTime()) < puzzleRefreshAge) {
+			return string(cached), nil
+		}
+	}
+
+	page, err := FetchPuzzle(day, session)
+	if err != nil {
+		return "", err
+	}
+	text := PuzzleMarkdown(page)
+	if text == "" {
+		return "", fmt.Errorf("day %d: no puzzle statement in the page", day)
+	}
+	if err := writeAtomic(path, []byte(text)); err != nil {
+		return "", fmt.Errorf("caching puzzle: %w", err)
+	}
+	return text, nil
+}
+
+// answerPattern finds the answer given for a part, which the page shows after
+// its article; the other patterns drive htmlToMarkdown
+var (
+	answerPattern  = regexp.MustCompile(`(?s)<p>Your puzzle answer was.*?</p>`)
+	elementPattern = regexp.MustCompile(`<(/?)([a-zA-Z0-9]+)([^>]*)>`)
+	hrefPattern    = regexp.MustCompile(`href="([^"]*)"`)
+
+	spacePattern      = regexp.MustCompile(`\s+`)
+	lineEdgePattern   = regexp.MustCompile(` *\n *`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// PuzzleMarkdown extracts the statement from a puzzle page as Markdown: each
+// part's article, followed by the answer already given for it, if any
+func PuzzleMarkdown(page []byte) string {
+	text := string(page)
+	// each part's statement is an article, as Submit's replies are
+	articles := articlePattern.FindAllStringSubmatchIndex(text, -1)
+
+	var b strings.Builder
+	for i, loc := range articles {
+		b.WriteString(htmlToMarkdown(text[loc[2]:loc[3]]))
+
+		// the answer paragraph sits between this article and the next
+		end := len(text)
+		if i+1 < len(articles) {
+			end = articles[i+1][0]
+		}
+		if answer := answerPattern.FindString(text[loc[1]:end]); answer != "" {
+			b.WriteString(htmlToMarkdown(answer))
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return strings.TrimSpace(b.String()) + "\n"
+}
+
+// htmlToMarkdown converts the handful of tags puzzle pages use: headings,
+// paragraphs, lists, code blocks, inline code, emphasis, and links
+func htmlToMarkdown(fragment string) string {
+	var b strings.Builder
+	var href []string // the open links' targets
+	inPre := false
+
+	// text outside <pre> collapses its whitespace, as a browser would;
+	// line breaks come only from the tags
+	writeText := func(text string) {
+		if !inPre {
+			text = spacePattern.ReplaceAllString(text, " ")
+		}
+		b.WriteString(html.UnescapeString(text))
+	}
+
+	last := 0
+	for _, m := range elementPattern.FindAllStringSubmatchIndex(fragment, -1) {
+		writeText(fragment[last:m[0]])
+		last = m[1]
+
+		closing := m[3] > m[2]
+		name := strings.ToLower(fragment[m[4]:m[5]])
+		attrs := fragment[m[6]:m[7]]
+
+		switch {
+		case name == "h2" && !closing:
+			b.WriteString("## ")
+		case name == "h2" || name == "p" || name == "ul":
+			if closing {
+				b.WriteString("\n\n")
+			}
+		case name == "li" && !closing:
+			b.WriteString("- ")
+		case name == "li":
+			b.WriteString("\n")
+		case name == "pre":
+			inPre = !closing
+			if closing {
+				b.WriteString("\n```\n\n")
+			} else {
+				b.WriteString("```\n")
+			}
+		case name == "code" && !inPre:
+			b.WriteString("`")
+		case name == "em" && !inPre:
+			b.WriteString("*")
+		case name == "a" && !closing:
+			target := ""
+			if h := hrefPattern.FindStringSubmatch(attrs); h != nil {
+				target = html.UnescapeString(h[1])
+			}
+			href = append(href, target)
+			b.WriteString("[")
+		case name == "a" && len(href) > 0:
+			target := href[len(href)-1]
+			href = href[:len(href)-1]
+			if strings.HasPrefix(target, "/") {
+				target = "https://adventofcode.com" + target
+			}
+			fmt.Fprintf(&b, "](%s)", target)
+		}
+	}
+	writeText(fragment[last:])
+
+	// drop the spaces left at line ends and the blank lines doubled up by
+	// adjacent blocks; pre blocks keep their own line breaks, so the closing
+	// fence's extra one goes too
+	text := strings.ReplaceAll(b.String(), "\n\n```", "\n```")
+
+	// only outside the code blocks, whose examples align columns with spaces:
+	// the even pieces between fences are prose
+	pieces := strings.Split(text, "```")
+	for i := 0; i < len(pieces); i += 2 {
+		pieces[i] = lineEdgePattern.ReplaceAllString(pieces[i], "\n")
+		pieces[i] = blankLinesPattern.ReplaceAllString(pieces[i], "\n\n")
+	}
+	return strings.Join(pieces, "```")
+}
@@ -0,0 +1,103 @@
+package fetch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const puzzlePart1 = `<html><body><main>
+<article class="day-desc"><h2>--- Day 1: Secret Entrance ---</h2>
+<p>The dial starts at <code>50</code> &amp; turns <em>left</em>. See <a href="/2025/about">about</a>.</p>
+<pre><code>L68
+<em>L30</em>
+</code></pre>
+<ul>
+<li>One</li>
+<li>Two</li>
+</ul>
+</article>
+<p>Your puzzle answer was <code>1147</code>.</p>`
+
+const puzzlePart2 = `
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2><p>Count every click.</p></article>
+</main></body></html>`
+
+func TestPuzzleMarkdown(t *testing.T) {
+	got := PuzzleMarkdown([]byte(puzzlePart1 + puzzlePart2))
+	want := "## --- Day 1: Secret Entrance ---\n\n" +
+		"The dial starts at `50` & turns *left*. See [about](https://adventofcode.com/2025/about).\n\n" +
+		"```\nL68\nL30\n```\n\n" +
+		"- One\n- Two\n\n" +
+		"Your puzzle answer was `1147`.\n\n" +
+		"## --- Part Two ---\n\nCount every click.\n"
+	if got != want {
+		t.Errorf("unexpected Markdown:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPuzzleMarkdownKeepsExampleSpaces(t *testing.T) {
+	page := `<article class="day-desc"><p>For example: </p>
+<pre><code>123 328  51 64 
+ 45 64  387 23 
+  6 98  215 314
+
+*   +   *   +  
+</code></pre>
+<p>Done.</p></article>`
+	want := "For example:\n\n" +
+		"```\n123 328  51 64 \n 45 64  387 23 \n  6 98  215 314\n\n*   +   *   +  \n```\n\n" +
+		"Done.\n"
+	if got := PuzzleMarkdown([]byte(page)); got != want {
+		t.Errorf("unexpected Markdown:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestEnsurePuzzleRefreshesPartTwo(t *testing.T) {
+	page := puzzlePart1
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/day/1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(page))
+	}))
+	t.Cleanup(srv.Close)
+	old, oldInterval := baseURL, minInterval
+	baseURL, minInterval = srv.URL, 0
+	t.Cleanup(func() { baseURL, minInterval = old, oldInterval })
+
+	path := filepath.Join(t.TempDir(), "puzzles", "day1.md")
+	for range 2 {
+		if text, err := EnsurePuzzle(path, 1, "", false); err != nil || strings.Contains(text, partTwoHeading) {
+			t.Fatalf("expected only part 1, got %v:\n%s", err, text)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("a fresh part 1 copy should be reused, got %d requests", requests)
+	}
+
+	// part 1 gets solved: an old part-1-only copy is fetched again
+	page += puzzlePart2
+	stale := time.Now().Add(-puzzleRefreshAge)
+	if err := os.Chtimes(path, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	if text, err := EnsurePuzzle(path, 1, "good", false); err != nil || !strings.Contains(text, partTwoHeading) {
+		t.Fatalf("expected part 2 after the refresh, got %v:\n%s", err, text)
+	}
+
+	// with both parts the copy is final, however old
+	if err := os.Chtimes(path, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := EnsurePuzzle(path, 1, "good", false); err != nil || requests != 2 {
+		t.Errorf("a complete copy should never be fetched again, got %d requests, %v", requests, err)
+	}
+}
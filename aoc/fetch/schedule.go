@@ -0,0 +1,43 @@
+package fetch
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Days is how many puzzles the 2025 event has
+const Days = 12
+
+// unlockZone is the site's clock: puzzles go live at midnight UTC-5 (EST)
+var unlockZone = time.FixedZone("EST", -5*60*60)
+
+// ErrLocked means the day's puzzle has not been released yet
+var ErrLocked = errors.New("puzzle not unlocked yet")
+
+// UnlockTime is when day's puzzle and input go live: midnight EST on that
+// day of December 2025
+func UnlockTime(day int) time.Time {
+	return time.Date(2025, time.December, day, 0, 0, 0, 0, unlockZone)
+}
+
+// CheckUnlocked returns an error wrapping ErrLocked when day is still locked
+// at now, saying how long is left
+func CheckUnlocked(day int, now time.Time) error {
+	if unlock := UnlockTime(day); now.Before(unlock) {
+		return fmt.Errorf("day %d: %w (unlocks %s, in %s)", day, ErrLocked,
+			unlock.Format("Mon Jan 2 15:04 MST"), unlock.Sub(now).Round(time.Second))
+	}
+	return nil
+}
+
+// Next returns the first day still locked at now, or false once the whole
+// event is out
+func Next(now time.Time) (int, bool) {
+	for day := 1; day <= Days; day++ {
+		if now.Before(UnlockTime(day)) {
+			return day, true
+		}
+	}
+	return 0, false
+}
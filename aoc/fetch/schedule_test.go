@@ -0,0 +1,36 @@
+package fetch
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUnlockTime(t *testing.T) {
+	want := time.Date(2025, time.December, 3, 5, 0, 0, 0, time.UTC)
+	if got := UnlockTime(3); !got.Equal(want) {
+		t.Errorf("expected day 3 at %v, got %v", want, got.UTC())
+	}
+}
+
+func TestCheckUnlocked(t *testing.T) {
+	unlock := UnlockTime(5)
+	if err := CheckUnlocked(5, unlock.Add(-time.Minute)); !errors.Is(err, ErrLocked) {
+		t.Errorf("expected ErrLocked a minute early, got %v", err)
+	}
+	if err := CheckUnlocked(5, unlock); err != nil {
+		t.Errorf("expected day 5 unlocked on time, got %v", err)
+	}
+}
+
+func TestNext(t *testing.T) {
+	if day, ok := Next(UnlockTime(4)); !ok || day != 5 {
+		t.Errorf("expected day 5 next once day 4 is out, got %d (%v)", day, ok)
+	}
+	if day, ok := Next(time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)); !ok || day != 1 {
+		t.Errorf("expected day 1 next before the event, got %d (%v)", day, ok)
+	}
+	if _, ok := Next(UnlockTime(Days)); ok {
+		t.Error("expected nothing left once the last day is out")
+	}
+}
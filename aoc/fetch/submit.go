@@ -0,0 +1,135 @@
+package fetch
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome classifies the site's reply to a submitted answer
+type Outcome int
+
+const (
+	Unknown       Outcome = iota // the reply did not match any known message
+	Correct                      // the answer was accepted
+	Incorrect                    // wrong, with no hint given
+	TooHigh                      // wrong, and the right answer is lower
+	TooLow                       // wrong, and the right answer is higher
+	TooSoon                      // rate limited; Verdict.Wait says how long
+	AlreadySolved                // the part was already completed
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Correct:
+		return "right answer"
+	case Incorrect:
+		return "wrong answer"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case TooSoon:
+		return "answered too recently"
+	case AlreadySolved:
+		return "already solved"
+	}
+	return "unrecognized response"
+}
+
+// Verdict is the parsed reply to a submission
+type Verdict struct {
+	Outcome Outcome
+
+	// Wait is how long the site asks to wait before the next attempt, when it
+	// says so (always for TooSoon, and after most wrong answers)
+	Wait time.Duration
+
+	// Message is the reply's text with the HTML stripped
+	Message string
+}
+
+// Submit posts answer for day and part and reports the site's verdict
+func Submit(day, part, answer int, session string) (Verdict, error) {
+	if session == "" {
+		return Verdict{}, ErrBadSession
+	}
+
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {strconv.Itoa(answer)}}
+	resp, err := do(http.MethodPost, fmt.Sprintf("/day/%d/answer", day), form, session)
+	if err != nil {
+		return Verdict{}, fmt.Errorf("submitting day %d part %d: %w", day, part, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest:
+		return Verdict{}, fmt.Errorf("day %d: %w", day, ErrBadSession)
+	case http.StatusNotFound:
+		return Verdict{}, fmt.Errorf("day %d: %w", day, ErrNotReleased)
+	default:
+		return Verdict{}, fmt.Errorf("day %d: unexpected HTTP status %s", day, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Verdict{}, fmt.Errorf("reading day %d reply: %w", day, err)
+	}
+	return parseVerdict(string(body)), nil
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	waitPattern    = regexp.MustCompile(`(?i)you have (?:(\d+)m )?(\d+)s left to wait|please wait (one|\d+) minutes?`)
+)
+
+// parseVerdict classifies a reply page by the message in its <article>
+func parseVerdict(page string) Verdict {
+	text := page
+	if m := articlePattern.FindStringSubmatch(page); m != nil {
+		text = m[1]
+	}
+	text = strings.Join(strings.Fields(html.UnescapeString(tagPattern.ReplaceAllString(text, ""))), " ")
+
+	v := Verdict{Message: text, Wait: parseWait(text)}
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		v.Outcome = Correct
+	case strings.Contains(text, "your answer is too high"):
+		v.Outcome = TooHigh
+	case strings.Contains(text, "your answer is too low"):
+		v.Outcome = TooLow
+	case strings.Contains(text, "That's not the right answer"):
+		v.Outcome = Incorrect
+	case strings.Contains(text, "You gave an answer too recently"):
+		v.Outcome = TooSoon
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		v.Outcome = AlreadySolved
+	}
+	return v
+}
+
+// parseWait reads "You have 1m 20s left to wait" or "Please wait 5 minutes"
+func parseWait(text string) time.Duration {
+	m := waitPattern.FindStringSubmatch(text)
+	switch {
+	case m == nil:
+		return 0
+	case m[3] == "one":
+		return time.Minute
+	case m[3] != "":
+		minutes, _ := strconv.Atoi(m[3])
+		return time.Duration(minutes) * time.Minute
+	}
+	minutes, _ := strconv.Atoi(m[1]) // empty when under a minute
+	seconds, _ := strconv.Atoi(m[2])
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+}
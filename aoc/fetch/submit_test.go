@@ -0,0 +1,72 @@
+package fetch
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func page(article string) string {
+	return "<html><body><main><article><p>" + article + "</p></article></main></body></html>"
+}
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    string
+		outcome Outcome
+		wait    time.Duration
+	}{
+		{"right", page(`That's the right answer! You are <span class="day-success">one gold star</span> closer.`), Correct, 0},
+		{"too high", page(`That's not the right answer; your answer is too high. Please wait one minute before trying again.`), TooHigh, time.Minute},
+		{"too low", page(`That's not the right answer; your answer is too low. Please wait 5 minutes before trying again.`), TooLow, 5 * time.Minute},
+		{"wrong", page(`That's not the right answer. If you're stuck, make sure you're using the full input data.`), Incorrect, 0},
+		{"too soon", page(`You gave an answer too recently; you have to wait after submitting an answer before trying again. You have 1m 20s left to wait.`), TooSoon, 80 * time.Second},
+		{"too soon seconds", page(`You gave an answer too recently. You have 34s left to wait.`), TooSoon, 34 * time.Second},
+		{"solved", page(`You don't seem to be solving the right level. Did you already complete it?`), AlreadySolved, 0},
+		{"unknown", "<html>maintenance</html>", Unknown, 0},
+	}
+	for _, tt := range tests {
+		v := parseVerdict(tt.page)
+		if v.Outcome != tt.outcome || v.Wait != tt.wait {
+			t.Errorf("%s: expected %v (wait %v), got %v (wait %v): %q", tt.name, tt.outcome, tt.wait, v.Outcome, v.Wait, v.Message)
+		}
+	}
+
+	if v := parseVerdict(page(`That's the <em>right answer</em>&#33;`)); v.Message != "That's the right answer!" {
+		t.Errorf("expected tags stripped and entities decoded, got %q", v.Message)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "good" {
+			http.Error(w, "bad session", http.StatusBadRequest)
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/day/3/answer" ||
+			r.FormValue("level") != "2" || r.FormValue("answer") != "1234" {
+			http.Error(w, "unexpected request", http.StatusTeapot)
+			return
+		}
+		w.Write([]byte(page("That's the right answer!")))
+	}))
+	t.Cleanup(srv.Close)
+	old, oldInterval := baseURL, minInterval
+	baseURL, minInterval = srv.URL, 0
+	t.Cleanup(func() { baseURL, minInterval = old, oldInterval })
+
+	v, err := Submit(3, 2, 1234, "good")
+	if err != nil || v.Outcome != Correct {
+		t.Errorf("expected a correct verdict, got %+v, %v", v, err)
+	}
+
+	if _, err := Submit(3, 2, 1234, "stale"); !errors.Is(err, ErrBadSession) {
+		t.Errorf("expected ErrBadSession, got %v", err)
+	}
+	if _, err := Submit(3, 2, 1234, ""); !errors.Is(err, ErrBadSession) {
+		t.Errorf("expected ErrBadSession without a session, got %v", err)
+	}
+}
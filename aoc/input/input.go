@@ -0,0 +1,20 @@
+// Package input opens puzzle input files for the day packages, which write
+// each part once against an io.Reader and solve files through SolveFile.
+package input
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// SolveFile opens path and hands its contents to solve
+func SolveFile(path string, solve func(io.Reader) (int, error)) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("loading input: opening file: %w", err)
+	}
+	defer file.Close()
+
+	return solve(file)
+}
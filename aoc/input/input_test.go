@@ -0,0 +1,39 @@
+package input
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSolveFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("12345\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := SolveFile(path, func(r io.Reader) (int, error) {
+		b, err := io.ReadAll(r)
+		return len(b), err
+	})
+	if err != nil || got != 6 {
+		t.Errorf("expected the file's 6 bytes, got %d, %v", got, err)
+	}
+}
+
+func TestSolveFileMissing(t *testing.T) {
+	called := false
+	_, err := SolveFile(filepath.Join(t.TempDir(), "missing.txt"), func(io.Reader) (int, error) {
+		called = true
+		return 0, nil
+	})
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a not-exist error, got %v", err)
+	}
+	if called {
+		t.Error("expected solve not to be called")
+	}
+}
@@ -0,0 +1,150 @@
+// Package plugin registers solvers from external executables, so solutions
+// built outside this module can be dropped into a plugins/ directory.
+//
+// A plugin is any executable file in the directory that speaks two commands:
+//
+//	PLUGIN parts              print one "DAY PART" line per part it solves
+//	PLUGIN solve DAY PART     read the puzzle input on stdin, print the answer
+//
+// A solve that exits non-zero fails, with its stderr as the error. The parts
+// join the registry next to the built-in days, which always win: a plugin
+// part that is already registered is rejected.
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"adv2025/aoc/registry"
+)
+
+// sources maps each registered plugin part to its executable
+var sources = make(map[[2]int]string)
+
+// Source returns the executable that solves day and part, if a plugin does
+func Source(day, part int) (string, bool) {
+	path, ok := sources[[2]int{day, part}]
+	if ok && !registry.Registered(day, part) {
+		return "", false // unregistered since
+	}
+	return path, ok
+}
+
+// Load registers the parts of every plugin in dir. A missing dir holds no
+// plugins. A broken plugin is reported without stopping the others loading.
+func Load(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading plugins: %w", err)
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Mode()&0o111 == 0 || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if err := register(filepath.Join(dir, entry.Name())); err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s: %w", entry.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// register asks the plugin at path for its parts and registers each one
+func register(path string) error {
+	out, err := run(path, nil, "parts")
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		day, part, err := parsePart(line)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if registry.Registered(day, part) {
+			if !registry.IsStub(day, part) {
+				errs = append(errs, fmt.Errorf("day %d part %d is already registered", day, part))
+				continue
+			}
+			// a built-in stub only holds the place until someone solves the day
+			registry.Unregister(day, part)
+		}
+
+		solveReader := func(r io.Reader) (int, error) {
+			return solve(path, day, part, r)
+		}
+		registry.Register(day, part, func(input string) (int, error) {
+			f, err := os.Open(input)
+			if err != nil {
+				return 0, fmt.Errorf("opening input: %w", err)
+			}
+			defer f.Close()
+			return solveReader(f)
+		})
+		registry.RegisterReader(day, part, solveReader)
+		sources[[2]int{day, part}] = path
+	}
+	return errors.Join(errs...)
+}
+
+// parsePart reads a "DAY PART" line of the parts listing
+func parsePart(line string) (day, part int, err error) {
+	fields := strings.Fields(line)
+	if len(fields) == 2 {
+		day, err = strconv.Atoi(fields[0])
+		if err == nil {
+			part, err = strconv.Atoi(fields[1])
+		}
+	}
+	if len(fields) != 2 || err != nil || day < 1 || part < 1 {
+		return 0, 0, fmt.Errorf("invalid part %q (want \"DAY PART\")", line)
+	}
+	return day, part, nil
+}
+
+// solve runs the plugin on one part, streaming the input to its stdin
+func solve(path string, day, part int, input io.Reader) (int, error) {
+	out, err := run(path, input, "solve", strconv.Itoa(day), strconv.Itoa(part))
+	if err != nil {
+		return 0, err
+	}
+	answer, err := strconv.Atoi(strings.TrimSpace(out))
+	if err != nil {
+		return 0, fmt.Errorf("plugin answer %q is not a number", strings.TrimSpace(out))
+	}
+	return answer, nil
+}
+
+// run executes the plugin and returns its stdout, or its stderr as the error
+func run(path string, stdin io.Reader, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", strings.Join(args, " "), msg)
+		}
+		return "", fmt.Errorf("%s: %w", strings.Join(args, " "), err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,131 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"adv2025/aoc/registry"
+)
+
+// writePlugin saves a shell script plugin in dir
+func writePlugin(t *testing.T, dir, name, script string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// unregisterAfter removes the given "DAY PART" pairs from the registry when
+// the test ends, so the tests can run again in the same process
+func unregisterAfter(t *testing.T, parts ...[2]int) {
+	t.Helper()
+	t.Cleanup(func() {
+		for _, p := range parts {
+			registry.Unregister(p[0], p[1])
+		}
+	})
+}
+
+// lookup finds a registered part
+func lookup(day, part int) (registry.Solver, bool) {
+	for _, s := range registry.All() {
+		if s.Day == day && s.Part == part {
+			return s, true
+		}
+	}
+	return registry.Solver{}, false
+}
+
+func TestLoad(t *testing.T) {
+	unregisterAfter(t, [2]int{90, 1}, [2]int{90, 2})
+	dir := t.TempDir()
+	// day 90 part 1 counts the input's lines; part 2 always fails
+	writePlugin(t, dir, "day90", `case "$1" in
+parts) echo "90 1"; echo "90 2" ;;
+solve) if [ "$3" = 2 ]; then echo "not solved yet" >&2; exit 1; fi; wc -l ;;
+esac
+`)
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Load(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s, ok := lookup(90, 1)
+	if !ok || s.SolveReader == nil {
+		t.Fatal("expected day 90 part 1 registered with a reader")
+	}
+	if got, err := s.SolveReader(strings.NewReader("a\nb\nc\n")); err != nil || got != 3 {
+		t.Errorf("expected 3 lines, got %d, %v", got, err)
+	}
+
+	input := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(input, []byte("a\nb\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := s.Solve(input); err != nil || got != 2 {
+		t.Errorf("expected 2 lines from the file, got %d, %v", got, err)
+	}
+
+	if path, ok := Source(90, 1); !ok || path != filepath.Join(dir, "day90") {
+		t.Errorf("expected day 90 part 1 to come from the day90 plugin, got %q", path)
+	}
+	if _, ok := Source(91, 1); ok {
+		t.Error("day 91 part 1 is no plugin part")
+	}
+
+	failing, _ := lookup(90, 2)
+	if _, err := failing.Solve(input); err == nil || !strings.Contains(err.Error(), "not solved yet") {
+		t.Errorf("expected the plugin's stderr as the error, got %v", err)
+	}
+}
+
+func TestLoadRejectsBuiltInAndInvalidParts(t *testing.T) {
+	unregisterAfter(t, [2]int{91, 1}, [2]int{92, 1})
+	registry.Register(91, 1, func(string) (int, error) { return 1, nil })
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "clash", `echo "91 1"; echo "91 x"; echo "92 1"`)
+
+	err := Load(dir)
+	if err == nil || !strings.Contains(err.Error(), "already registered") || !strings.Contains(err.Error(), `"91 x"`) {
+		t.Errorf("expected the clash and the bad line reported, got %v", err)
+	}
+	if _, ok := lookup(92, 1); !ok {
+		t.Error("the plugin's valid part should still be registered")
+	}
+}
+
+func TestLoadReplacesStubs(t *testing.T) {
+	unregisterAfter(t, [2]int{93, 1})
+	registry.Register(93, 1, func(string) (int, error) { return 0, nil })
+	registry.MarkStub(93, 1)
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "day93", `case "$1" in
+parts) echo "93 1" ;;
+solve) echo 42 ;;
+esac
+`)
+	if err := Load(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s, ok := lookup(93, 1)
+	if !ok || s.Stub || s.SolveReader == nil {
+		t.Fatalf("expected the plugin to replace the stub, got %+v", s)
+	}
+	if got, err := s.SolveReader(strings.NewReader("")); err != nil || got != 42 {
+		t.Errorf("expected the plugin's answer 42, got %d, %v", got, err)
+	}
+}
+
+func TestLoadMissingDir(t *testing.T) {
+	if err := Load(filepath.Join(t.TempDir(), "plugins")); err != nil {
+		t.Errorf("a missing directory should hold no plugins, got %v", err)
+	}
+}
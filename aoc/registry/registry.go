@@ -0,0 +1,143 @@
+// Package registry collects the puzzle solvers that each day package
+// registers from its init function, so the runner never lists days by hand.
+//
+// A day wires itself in with
+//
+//	func init() {
+//		registry.Register(5, 1, Part1)
+//	}
+//
+// and the runner blank-imports the package to trigger that init.
+package registry
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Solver is one registered puzzle part
+type Solver struct {
+	Day, Part int
+
+	// Solve reads the input from a file path
+	Solve func(string) (int, error)
+
+	// SolveReader reads the input from an io.Reader; nil when the day has none
+	SolveReader func(io.Reader) (int, error)
+
+	// SolveContext is Solve for parts that stop early once ctx is cancelled;
+	// nil when the part does not check for cancellation
+	SolveContext func(context.Context, string) (int, error)
+
+	// SolveProgress is Solve for parts that report how far along they are;
+	// nil when the part does not report progress
+	SolveProgress func(string, Progress) (int, error)
+
+	// Stub is set for placeholder parts that do not solve the puzzle yet
+	Stub bool
+}
+
+// Progress receives how much of a solve is complete, as done out of total in
+// whatever unit the part counts (ranges, rows, rounds, ...)
+type Progress func(done, total int)
+
+type key struct{ day, part int }
+
+var solvers = make(map[key]*Solver)
+
+// Register adds the solver for day and part. Registering the same day and
+// part twice is a programming mistake and panics at startup.
+func Register(day, part int, fn func(string) (int, error)) {
+	k := key{day, part}
+	if _, ok := solvers[k]; ok {
+		panic(fmt.Sprintf("registry: day %d part %d registered twice", day, part))
+	}
+	solvers[k] = &Solver{Day: day, Part: part, Solve: fn}
+}
+
+// Registered reports whether day and part already have a solver, so code
+// registering at run time (such as plugins) can avoid Register's panic
+func Registered(day, part int) bool {
+	_, ok := solvers[key{day, part}]
+	return ok
+}
+
+// IsStub reports whether day and part are registered as a placeholder with
+// MarkStub, which a real solution may replace
+func IsStub(day, part int) bool {
+	s, ok := solvers[key{day, part}]
+	return ok && s.Stub
+}
+
+// Unregister removes the solver for day and part, if any, so parts added at
+// run time (and the tests that add them) can be undone
+func Unregister(day, part int) {
+	delete(solvers, key{day, part})
+}
+
+// RegisterReader attaches an io.Reader entry point to a part that has already
+// been registered with Register. It panics if the part is unknown or already
+// has a reader.
+func RegisterReader(day, part int, fn func(io.Reader) (int, error)) {
+	s, ok := solvers[key{day, part}]
+	if !ok {
+		panic(fmt.Sprintf("registry: reader for day %d part %d registered before its solver", day, part))
+	}
+	if s.SolveReader != nil {
+		panic(fmt.Sprintf("registry: reader for day %d part %d registered twice", day, part))
+	}
+	s.SolveReader = fn
+}
+
+// RegisterContext attaches a cancellable entry point to a part that has
+// already been registered with Register. It panics if the part is unknown or
+// already has one.
+func RegisterContext(day, part int, fn func(context.Context, string) (int, error)) {
+	s, ok := solvers[key{day, part}]
+	if !ok {
+		panic(fmt.Sprintf("registry: context solver for day %d part %d registered before its solver", day, part))
+	}
+	if s.SolveContext != nil {
+		panic(fmt.Sprintf("registry: context solver for day %d part %d registered twice", day, part))
+	}
+	s.SolveContext = fn
+}
+
+// RegisterProgress attaches a progress-reporting entry point to a part that
+// has already been registered with Register. It panics if the part is unknown
+// or already has one.
+func RegisterProgress(day, part int, fn func(string, Progress) (int, error)) {
+	s, ok := solvers[key{day, part}]
+	if !ok {
+		panic(fmt.Sprintf("registry: progress solver for day %d part %d registered before its solver", day, part))
+	}
+	if s.SolveProgress != nil {
+		panic(fmt.Sprintf("registry: progress solver for day %d part %d registered twice", day, part))
+	}
+	s.SolveProgress = fn
+}
+
+// MarkStub flags an already registered part as a placeholder, so tools can
+// tell it from a real solution. It panics if the part is unknown.
+func MarkStub(day, part int) {
+	s, ok := solvers[key{day, part}]
+	if !ok {
+		panic(fmt.Sprintf("registry: stub day %d part %d marked before its solver", day, part))
+	}
+	s.Stub = true
+}
+
+// All returns every registered solver sorted by day, then part
+func All() []Solver {
+	all := make([]Solver, 0, len(solvers))
+	for _, s := range solvers {
+		all = append(all, *s)
+	}
+	slices.SortFunc(all, func(a, b Solver) int {
+		return cmp.Or(cmp.Compare(a.Day, b.Day), cmp.Compare(a.Part, b.Part))
+	})
+	return all
+}
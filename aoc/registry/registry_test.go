@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// isolate swaps in an empty registry for the duration of the test
+func isolate(t *testing.T) {
+	t.Helper()
+
+	saved := solvers
+	solvers = make(map[key]*Solver)
+	t.Cleanup(func() { solvers = saved })
+}
+
+func fixed(value int) func(string) (int, error) {
+	return func(string) (int, error) { return value, nil }
+}
+
+// expectPanic runs fn and returns the panic message it raises
+func expectPanic(t *testing.T, fn func()) string {
+	t.Helper()
+
+	var msg string
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				msg, _ = r.(string)
+			}
+		}()
+		fn()
+	}()
+	if msg == "" {
+		t.Fatal("expected a panic")
+	}
+	return msg
+}
+
+func TestAllIsSorted(t *testing.T) {
+	isolate(t)
+	Register(10, 2, fixed(102))
+	Register(2, 1, fixed(21))
+	Register(10, 1, fixed(101))
+	Register(2, 2, fixed(22))
+
+	var got []int
+	for _, s := range All() {
+		got = append(got, s.Day*10+s.Part)
+		if v, _ := s.Solve(""); v != s.Day*10+s.Part {
+			t.Errorf("day %d part %d: solver returned %d", s.Day, s.Part, v)
+		}
+	}
+	want := []int{21, 22, 101, 102}
+	for i := range want {
+		if i >= len(got) || got[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, got)
+		}
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	isolate(t)
+	Register(1, 1, fixed(1))
+
+	msg := expectPanic(t, func() { Register(1, 1, fixed(2)) })
+	if !strings.Contains(msg, "day 1 part 1 registered twice") {
+		t.Errorf("unexpected panic message %q", msg)
+	}
+}
+
+func TestRegisterReader(t *testing.T) {
+	isolate(t)
+	Register(3, 1, fixed(0))
+	RegisterReader(3, 1, func(r io.Reader) (int, error) {
+		data, err := io.ReadAll(r)
+		return len(data), err
+	})
+
+	s := All()[0]
+	if n, _ := s.SolveReader(strings.NewReader("abcd")); n != 4 {
+		t.Errorf("expected the reader solver to see 4 bytes, got %d", n)
+	}
+
+	expectPanic(t, func() { RegisterReader(3, 1, s.SolveReader) })
+	expectPanic(t, func() { RegisterReader(3, 2, s.SolveReader) })
+}
+
+func TestRegisterContext(t *testing.T) {
+	isolate(t)
+	Register(4, 2, fixed(0))
+	RegisterContext(4, 2, func(ctx context.Context, _ string) (int, error) {
+		return 0, ctx.Err()
+	})
+
+	s := All()[0]
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.SolveContext(ctx, ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the context solver to see the cancellation, got %v", err)
+	}
+
+	expectPanic(t, func() { RegisterContext(4, 2, s.SolveContext) })
+	expectPanic(t, func() { RegisterContext(4, 1, s.SolveContext) })
+}
+
+func TestRegisterProgress(t *testing.T) {
+	isolate(t)
+	Register(2, 2, fixed(0))
+	RegisterProgress(2, 2, func(_ string, progress Progress) (int, error) {
+		for i := range 3 {
+			progress(i+1, 3)
+		}
+		return 0, nil
+	})
+
+	s := All()[0]
+	var reports []int
+	s.SolveProgress("", func(done, total int) { reports = append(reports, done*10+total) })
+	if len(reports) != 3 || reports[2] != 33 {
+		t.Errorf("expected progress 1/3, 2/3, 3/3, got %v", reports)
+	}
+
+	expectPanic(t, func() { RegisterProgress(2, 2, s.SolveProgress) })
+	expectPanic(t, func() { RegisterProgress(2, 1, s.SolveProgress) })
+}
+
+func TestMarkStub(t *testing.T) {
+	isolate(t)
+	Register(9, 1, fixed(0))
+	Register(9, 2, fixed(0))
+	MarkStub(9, 2)
+
+	if all := All(); all[0].Stub || !all[1].Stub {
+		t.Errorf("expected only day 9 part 2 to be a stub, got %v and %v", all[0].Stub, all[1].Stub)
+	}
+	expectPanic(t, func() { MarkStub(9, 3) })
+}
+
+func TestRegistered(t *testing.T) {
+	isolate(t)
+	Register(3, 1, fixed(1))
+
+	if !Registered(3, 1) || Registered(3, 2) {
+		t.Error("expected only day 3 part 1 to be registered")
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	isolate(t)
+	Register(4, 1, fixed(41))
+	Unregister(4, 1)
+	Unregister(4, 2) // unknown parts are ignored
+
+	if Registered(4, 1) {
+		t.Error("expected day 4 part 1 to be gone")
+	}
+	Register(4, 1, fixed(42)) // no longer a duplicate
+	if v, _ := All()[0].Solve(""); v != 42 {
+		t.Errorf("expected the new solver, got %d", v)
+	}
+}
+
+func TestIsStub(t *testing.T) {
+	isolate(t)
+	Register(7, 1, fixed(0))
+	Register(7, 2, fixed(0))
+	MarkStub(7, 1)
+
+	if !IsStub(7, 1) || IsStub(7, 2) || IsStub(8, 1) {
+		t.Error("expected only day 7 part 1 to be a stub")
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"adv2025/aoc/fetch"
+)
+
+// badgeStart and badgeEnd mark the section of a Markdown file that
+// "badge -format markdown -out FILE" rewrites; everything around it is kept
+const (
+	badgeStart = "<!-- badge:start -->"
+	badgeEnd   = "<!-- badge:end -->"
+)
+
+// runBadge solves every registered part, checks each answer against the
+// answers file, and draws the stars earned as an SVG badge or a Markdown table
+func runBadge(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("badge", flag.ContinueOnError)
+	format := fs.String("format", "svg", "Badge format: svg or markdown")
+	outPath := fs.String("out", "", "Write the SVG to `FILE`, or replace the marked table in a Markdown FILE (default stdout)")
+	fs.StringVar(&inputsDir, "inputs-dir", defaultInputsDir(), "Directory holding dayN_input.txt (default $AOC_INPUTS_DIR, then ./inputs)")
+	answersPath := fs.String("answers", "", "Known answers file (default answers.json, then answers.txt)")
+	if err := parseSubcommand(fs, args); err != nil {
+		return err
+	}
+
+	var render func(*badgeReporter) []byte
+	switch *format {
+	case "svg":
+		render = (*badgeReporter).svg
+	case "markdown":
+		render = (*badgeReporter).markdown
+	default:
+		return fmt.Errorf("unknown badge format %q (want svg or markdown)", *format)
+	}
+
+	path, err := findAnswers(*answersPath)
+	if err != nil {
+		return err
+	}
+	known, err := loadAnswers(path)
+	if err != nil {
+		return err
+	}
+
+	badge := newBadgeReporter(known)
+	if _, err := runAll(badge, solvers); err != nil {
+		return err
+	}
+	out := render(badge)
+
+	switch {
+	case *outPath == "":
+		_, err = w.Write(out)
+		return err
+	case *format == "markdown":
+		err = replaceBadgeSection(*outPath, out)
+	default:
+		err = os.WriteFile(*outPath, out, 0o644)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s: %d/%d stars in %s\n", sym.star, *outPath, badge.stars(), 2*fetch.Days, formatBadgeDuration(badge.total))
+	return err
+}
+
+// badgeDay is one day's column: stars earned (0 to 2) and the time its parts took
+type badgeDay struct {
+	stars   int
+	elapsed time.Duration
+}
+
+// badgeReporter tallies the stars of a run: a part earns its star when it
+// matches the recorded answer, so an unverified part earns none
+type badgeReporter struct {
+	answers answers
+	days    [fetch.Days + 1]badgeDay // indexed by day
+	total   time.Duration
+}
+
+func newBadgeReporter(known answers) *badgeReporter {
+	return &badgeReporter{answers: known}
+}
+
+func (b *badgeReporter) Start() {}
+
+func (b *badgeReporter) Result(r result) {
+	if r.day < 1 || r.day > fetch.Days {
+		return
+	}
+	b.days[r.day].elapsed += r.elapsed
+	if want, ok := b.answers.lookup(r.day, r.part); ok && r.err == nil && r.value == want {
+		b.days[r.day].stars++
+	}
+}
+
+func (b *badgeReporter) Finish(total time.Duration) error {
+	b.total = total
+	return nil
+}
+
+func (b *badgeReporter) stars() int {
+	n := 0
+	for _, d := range b.days {
+		n += d.stars
+	}
+	return n
+}
+
+// badgeColors fill a day's cell by stars earned, as the site colours them
+var badgeColors = [3]string{"#333340", "#9999cc", "#ffff66"}
+
+// svg draws a title line with the totals over one cell per day
+func (b *badgeReporter) svg() []byte {
+	const cell, gap, pad = 14, 4, 8
+	width := 2*pad + fetch.Days*(cell+gap) - gap
+	label := fmt.Sprintf("AoC 2025 ★ %d/%d · %s", b.stars(), 2*fetch.Days, formatBadgeDuration(b.total))
+
+	var s bytes.Buffer
+	fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="40" role="img" aria-label="%s">`+"\n", width, html.EscapeString(label))
+	fmt.Fprintf(&s, "  <title>%s</title>\n", html.EscapeString(label))
+	fmt.Fprintf(&s, `  <rect width="%d" height="40" rx="4" fill="#0f0f23"/>`+"\n", width)
+	fmt.Fprintf(&s, `  <text x="%d" y="16" fill="#cccccc" font-family="monospace" font-size="12">%s</text>`+"\n", pad, html.EscapeString(label))
+	for day := 1; day <= fetch.Days; day++ {
+		d := b.days[day]
+		fmt.Fprintf(&s, `  <rect x="%d" y="22" width="%d" height="12" rx="2" fill="%s"><title>Day %d: %d stars, %s</title></rect>`+"\n",
+			pad+(day-1)*(cell+gap), cell, badgeColors[d.stars], day, d.stars, formatBadgeDuration(d.elapsed))
+	}
+	s.WriteString("</svg>\n")
+	return s.Bytes()
+}
+
+// markdown is a table of every day's stars and time, between the badge markers
+func (b *badgeReporter) markdown() []byte {
+	var s bytes.Buffer
+	s.WriteString(badgeStart + "\n")
+	s.WriteString("| Day | Stars | Time |\n|--:|:--|--:|\n")
+	for day := 1; day <= fetch.Days; day++ {
+		d := b.days[day]
+		elapsed := "-"
+		if d.elapsed > 0 {
+			elapsed = formatBadgeDuration(d.elapsed)
+		}
+		fmt.Fprintf(&s, "| %d | %s | %s |\n", day, strings.Repeat("⭐", d.stars), elapsed)
+	}
+	fmt.Fprintf(&s, "| **Total** | **%d/%d** | **%s** |\n", b.stars(), 2*fetch.Days, formatBadgeDuration(b.total))
+	s.WriteString(badgeEnd + "\n")
+	return s.Bytes()
+}
+
+// formatBadgeDuration rounds d for display; badges are committed, so they
+// keep the µ regardless of -plain
+func formatBadgeDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	}
+	return d.Round(time.Microsecond).String()
+}
+
+// replaceBadgeSection swaps the marked section of the Markdown file at path
+// for section, which carries its own markers
+func replaceBadgeSection(path string, section []byte) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	start := bytes.Index(data, []byte(badgeStart))
+	end := bytes.Index(data, []byte(badgeEnd))
+	if start < 0 || end < start {
+		return fmt.Errorf("%s: no badge section; add %s and %s lines where the table belongs", path, badgeStart, badgeEnd)
+	}
+	end += len(badgeEnd)
+	if end < len(data) && data[end] == '\n' {
+		end++
+	}
+
+	updated := append(append(append([]byte{}, data[:start]...), section...), data[end:]...)
+	return os.WriteFile(path, updated, 0o644)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBadgeReporterCountsVerifiedStars(t *testing.T) {
+	badge := newBadgeReporter(answers{1: {1: 10, 2: 20}, 2: {1: 30, 2: 40}})
+	badge.Start()
+	for _, r := range []result{
+		{day: 1, part: 1, value: 10, elapsed: time.Millisecond},
+		{day: 1, part: 2, value: 20, elapsed: time.Millisecond},
+		{day: 2, part: 1, value: 30, elapsed: time.Millisecond},
+		{day: 2, part: 2, value: 41},               // wrong answer
+		{day: 3, part: 1, value: 50},               // no recorded answer
+		{day: 3, part: 2, err: errors.New("boom")}, // solver error
+	} {
+		badge.Result(r)
+	}
+	if err := badge.Finish(3 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := badge.stars(); got != 3 {
+		t.Errorf("expected 3 stars, got %d", got)
+	}
+
+	svg := string(badge.svg())
+	for _, want := range []string{"AoC 2025 ★ 3/24 · 3ms", `fill="#ffff66"><title>Day 1: 2 stars, 2ms`, `fill="#9999cc"><title>Day 2: 1 stars`, `fill="#333340"><title>Day 12: 0 stars`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("expected the SVG to contain %q:\n%s", want, svg)
+		}
+	}
+
+	md := string(badge.markdown())
+	for _, want := range []string{"| 1 | ⭐⭐ | 2ms |\n", "| 2 | ⭐ | 1ms |\n", "| 12 |  | - |\n", "| **Total** | **3/24** | **3ms** |\n"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected the table to contain %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestReplaceBadgeSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "README.md")
+	readme := "# Title\n\n" + badgeStart + "\nold table\n" + badgeEnd + "\n\nMore text\n"
+	if err := os.WriteFile(path, []byte(readme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	section := badgeStart + "\nnew table\n" + badgeEnd + "\n"
+	for range 2 { // rewriting is idempotent
+		if err := replaceBadgeSection(path, []byte(section)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Title\n\n" + section + "\nMore text\n"; string(got) != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if err := os.WriteFile(path, []byte("# No markers\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := replaceBadgeSection(path, []byte(section)); err == nil || !strings.Contains(err.Error(), badgeStart) {
+		t.Errorf("expected an error naming the markers, got %v", err)
+	}
+}
+
+func TestRunBadgeRejectsUnknownFormat(t *testing.T) {
+	if err := runBadge(&strings.Builder{}, []string{"-format", "png"}); err == nil || !strings.Contains(err.Error(), "png") {
+		t.Errorf("expected an unknown-format error, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+)
+
+// runBenchGo is runBench writing Go's benchmark format instead of a table:
+// one "BenchmarkDayNPartM-P 1 X ns/op" line per timed run, the layout go test
+// -bench -count=N produces, so benchstat can compare two saved runs. Failures
+// are reported the way go test reports them. It reports whether every solver
+// ran.
+func runBenchGo(w io.Writer, toRun []solver, warmup, iterations int) bool {
+	fmt.Fprintf(w, "goos: %s\ngoarch: %s\npkg: adv2025/cmd\n", runtime.GOOS, runtime.GOARCH)
+
+	ok := true
+	for _, s := range toRun {
+		name := benchName(s.day, s.part)
+
+		path := inputPath(s.day, s.part)
+		if _, err := os.Stat(path); err != nil {
+			ok = false
+			fmt.Fprintf(w, "--- FAIL: %s\n    %v\n", name, errInputNotFound)
+			continue
+		}
+
+		samples, err := benchSamples(s, path, warmup, iterations)
+		if err != nil {
+			ok = false
+			fmt.Fprintf(w, "--- FAIL: %s\n    %v\n", name, err)
+			continue
+		}
+		for _, d := range samples {
+			fmt.Fprintf(w, "%s\t%8d\t%12d ns/op\n", name, 1, d.Nanoseconds())
+		}
+	}
+
+	if ok {
+		fmt.Fprintln(w, "PASS")
+	} else {
+		fmt.Fprintln(w, "FAIL")
+	}
+	return ok
+}
+
+// benchName is the benchmark name go test would print for the part, including
+// the GOMAXPROCS suffix it adds when that is above 1
+func benchName(day, part int) string {
+	name := fmt.Sprintf("BenchmarkDay%dPart%d", day, part)
+	if procs := runtime.GOMAXPROCS(0); procs > 1 {
+		name += fmt.Sprintf("-%d", procs)
+	}
+	return name
+}
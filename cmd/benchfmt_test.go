@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRunBenchGo(t *testing.T) {
+	withInputs(t, 1)
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	var out bytes.Buffer
+	if !runBenchGo(&out, []solver{{day: 1, part: 1, solve: fixed(1)}}, 1, 3) {
+		t.Fatal("expected the bench run to succeed")
+	}
+
+	line := regexp.MustCompile(`(?m)^BenchmarkDay1Part1\t +1\t +\d+ ns/op$`)
+	if got := out.String(); len(line.FindAllString(got, -1)) != 3 || !strings.HasSuffix(got, "PASS\n") {
+		t.Errorf("expected 3 benchmark lines and PASS:\n%s", got)
+	}
+}
+
+func TestRunBenchGoFailure(t *testing.T) {
+	withInputs(t)
+
+	var out bytes.Buffer
+	if runBenchGo(&out, []solver{{day: 2, part: 1, solve: fixed(1)}}, 0, 1) {
+		t.Error("expected a missing input to fail the bench run")
+	}
+	if got := out.String(); !strings.Contains(got, "--- FAIL: BenchmarkDay2Part1") || !strings.HasSuffix(got, "FAIL\n") {
+		t.Errorf("expected a go test style failure:\n%s", got)
+	}
+}
+
+func TestBenchName(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	if got := benchName(4, 2); got != "BenchmarkDay4Part2-8" {
+		t.Errorf("expected the GOMAXPROCS suffix, got %q", got)
+	}
+}
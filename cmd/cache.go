@@ -0,0 +1,117 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// cachePath is where answers are kept between runs
+var cachePath = filepath.Join(".aoc", "cache", "answers.json")
+
+// answerCache holds the answers of earlier runs; nil disables caching
+// (-no-cache, and every mode that measures the solvers)
+var answerCache *cache
+
+// cache maps a part, its variant, and the SHA-256 of its input to the answer.
+// Entries are only valid for the binary that computed them, so any code
+// change, and with it every go run after an edit, starts an empty cache.
+type cache struct {
+	mu      sync.Mutex
+	path    string
+	Build   string         `json:"build"`
+	Answers map[string]int `json:"answers"`
+	dirty   bool
+}
+
+// openCache loads the cache at path for the running binary. A missing,
+// corrupt, or stale cache is simply empty.
+func openCache(path string) (*cache, error) {
+	build, err := executableHash()
+	if err != nil {
+		return nil, fmt.Errorf("identifying the runner binary: %w", err)
+	}
+
+	c := &cache{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, c) != nil || c.Build != build || c.Answers == nil {
+		c.Build, c.Answers = build, make(map[string]int)
+	}
+	return c, nil
+}
+
+func executableHash() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return fileHash(exe)
+}
+
+func fileHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cacheKey identifies s's answer for the input with inputHash: "day.part.hash",
+// or "day.part.variant.hash" for a solver whose settings change the answer
+func cacheKey(s solver, inputHash string) string {
+	if s.variant != "" {
+		return fmt.Sprintf("%d.%d.%s.%s", s.day, s.part, s.variant, inputHash)
+	}
+	return fmt.Sprintf("%d.%d.%s", s.day, s.part, inputHash)
+}
+
+func (c *cache) lookup(key string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	answer, ok := c.Answers[key]
+	return answer, ok
+}
+
+func (c *cache) store(key string, answer int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Answers[key] = answer
+	c.dirty = true
+}
+
+// save writes the cache back if this run added answers
+func (c *cache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	// write then rename, so an interrupted run never leaves half a cache
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing answer cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("writing answer cache: %w", err)
+	}
+	return nil
+}
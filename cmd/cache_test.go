@@ -0,0 +1,144 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"adv2025/aoc/plugin"
+	"adv2025/aoc/registry"
+)
+
+// useCache enables the answer cache for the test, stored under its temp dir
+func useCache(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "answers.json")
+	c, err := openCache(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	answerCache = c
+	t.Cleanup(func() { answerCache = nil })
+	return path
+}
+
+func TestRunSolverCache(t *testing.T) {
+	withInputs(t, 1)
+	path := useCache(t)
+
+	calls := 0
+	s := solver{day: 1, part: 1, solve: func(string) (int, error) { calls++; return 42, nil }}
+	if r := runSolver(s); r.cached || r.value != 42 {
+		t.Fatalf("expected a fresh answer on the first run, got %+v", r)
+	}
+	if r := runSolver(s); !r.cached || r.value != 42 || calls != 1 {
+		t.Errorf("expected the cached answer without running the solver, got %+v after %d calls", r, calls)
+	}
+
+	if err := answerCache.save(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := openCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answerCache = reopened
+	if r := runSolver(s); !r.cached || calls != 1 {
+		t.Errorf("expected the answer to survive a save, got %+v", r)
+	}
+
+	// a different input is a different key
+	if err := os.WriteFile(inputPathFor(1), []byte("L5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if r := runSolver(s); r.cached || calls != 2 {
+		t.Errorf("a changed input should run the solver again, got %+v", r)
+	}
+}
+
+func TestCacheSkipsFailures(t *testing.T) {
+	withInputs(t, 1)
+	useCache(t)
+
+	calls := 0
+	s := solver{day: 1, part: 1, solve: func(string) (int, error) { calls++; return 0, errInputNotFound }}
+	runSolver(s)
+	if r := runSolver(s); r.cached || calls != 2 {
+		t.Errorf("failed solves should not be cached, got %+v", r)
+	}
+}
+
+func TestOpenCacheStaleBuild(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	if err := os.WriteFile(path, []byte(`{"build":"old","answers":{"1.1.abc":5}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := openCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.lookup(cacheKey(solver{day: 1, part: 1}, "abc")); ok {
+		t.Error("answers from another build should be dropped")
+	}
+}
+
+func TestCacheKeepsStartValuesApart(t *testing.T) {
+	withInputs(t)
+	if err := os.WriteFile(inputPathFor(1), []byte("R10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	useCache(t)
+
+	part1 := []solver{{day: 1, part: 1, solve: fixed(0)}}
+	for _, tt := range []struct {
+		start  int
+		want   int
+		cached bool
+	}{
+		{90, 1, false}, // R10 from 90 lands on 0
+		{20, 0, false}, // a new -start is solved, not served 90's answer
+		{90, 1, true},
+	} {
+		r := runSolver(withStart(part1, tt.start)[0])
+		if r.err != nil || r.value != tt.want || r.cached != tt.cached {
+			t.Errorf("-start %d: expected %d (cached %v), got %+v", tt.start, tt.want, tt.cached, r)
+		}
+	}
+}
+
+func TestCacheKeySeparatesPluginBuilds(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "day95")
+	write := func(answer string) {
+		t.Helper()
+		body := "#!/bin/sh\nif [ \"$1\" = parts ]; then echo \"95 1\"; else echo " + answer + "; fi\n"
+		if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		registry.Unregister(95, 1)
+		collectSolvers()
+	})
+
+	write("1")
+	if err := plugin.Load(dir); err != nil {
+		t.Fatal(err)
+	}
+	key := func() string {
+		collectSolvers()
+		for _, s := range solvers {
+			if s.day == 95 {
+				return cacheKey(s, "input")
+			}
+		}
+		t.Fatal("plugin part not collected")
+		return ""
+	}
+
+	before := key()
+	write("2") // the plugin is rebuilt
+	if after := key(); after == before {
+		t.Errorf("a rebuilt plugin should get a new cache key, both are %q", after)
+	}
+}
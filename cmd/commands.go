@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"adv2025/aoc/fetch"
+)
+
+// commands are the runner's subcommands, selected by the first argument as in
+// "go run ./cmd fetch -day 3". Without one the runner solves puzzles.
+var commands = map[string]func(w io.Writer, args []string) error{
+	"badge":       runBadge,
+	"doctor":      runDoctor,
+	"fetch":       runFetch,
+	"leaderboard": runLeaderboard,
+	"list":        runList,
+	"new":         runNew,
+	"next":        runNext,
+	"puzzle":      runPuzzle,
+	"serve":       runServe,
+	"submit":      runSubmit,
+}
+
+// runFetch downloads the missing inputs of every registered day, or of -day
+func runFetch(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	day := fs.Int("day", 0, "Day to download (0 for every registered day)")
+	fs.StringVar(&inputsDir, "inputs-dir", defaultInputsDir(), "Directory to save dayN_input.txt in (default $AOC_INPUTS_DIR, then ./inputs)")
+	session := fs.String("session", os.Getenv("AOC_SESSION"), "adventofcode.com session cookie (default $AOC_SESSION)")
+	force := fs.Bool("force", false, "Try days that have not unlocked yet")
+	if err := parseSubcommand(fs, args); err != nil {
+		return err
+	}
+	if *session == "" {
+		return errors.New("fetch needs a session cookie: set AOC_SESSION or pass -session")
+	}
+
+	days := registeredDays()
+	if *day != 0 {
+		if err := checkUnlocked(*day, *force); err != nil {
+			return err
+		}
+		days = []int{*day}
+	}
+
+	var errs []error
+	for _, d := range days {
+		path := inputPathFor(d)
+		if _, err := os.Stat(path); err == nil {
+			fmt.Fprintf(w, "%sDay %d: %s already present\n", sym.present, d, path)
+			continue
+		}
+		if !*force && fetch.CheckUnlocked(d, now()) != nil {
+			// skipped rather than failed: the day is simply not out yet
+			fmt.Fprintf(w, "%sDay %d: skipped, unlocks %s\n", sym.skip, d, fetch.UnlockTime(d).Format("Mon Jan 2 15:04 MST"))
+			continue
+		}
+		if err := fetch.EnsureInput(path, d, *session); err != nil {
+			fmt.Fprintf(w, "%sDay %d: %v\n", sym.fail, d, err)
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Fprintf(w, "%sDay %d: saved %s\n", sym.download, d, path)
+	}
+	return errors.Join(errs...)
+}
+
+// registeredDays lists each day with at least one registered solver, in order
+func registeredDays() []int {
+	var days []int
+	for _, s := range solvers {
+		if len(days) == 0 || days[len(days)-1] != s.day {
+			days = append(days, s.day)
+		}
+	}
+	return days
+}
+
+// runSubmit solves one part and posts the answer, reporting the site's
+// verdict. Anything but an accepted (or already accepted) answer is an error.
+func runSubmit(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	day := fs.Int("day", 0, "Day to submit")
+	part := fs.Int("part", 0, "Part to submit")
+	fs.StringVar(&inputsDir, "inputs-dir", defaultInputsDir(), "Directory holding dayN_input.txt (default $AOC_INPUTS_DIR, then ./inputs)")
+	session := fs.String("session", os.Getenv("AOC_SESSION"), "adventofcode.com session cookie (default $AOC_SESSION)")
+	force := fs.Bool("force", false, "Submit even if the day has not unlocked yet")
+	if err := parseSubcommand(fs, args); err != nil {
+		return err
+	}
+	if *day == 0 || *part == 0 {
+		return errors.New("submit needs -day and -part")
+	}
+	if err := checkUnlocked(*day, *force); err != nil {
+		return err
+	}
+	if *session == "" {
+		return errors.New("submit needs a session cookie: set AOC_SESSION or pass -session")
+	}
+
+	toRun := filterSolvers(*day, *part)
+	if len(toRun) == 0 {
+		return fmt.Errorf("no solution found for day %d part %d", *day, *part)
+	}
+	r := runSolver(toRun[0])
+	if r.err != nil {
+		return fmt.Errorf("solving day %d part %d: %w", *day, *part, r.err)
+	}
+
+	fmt.Fprintf(w, "%sDay %d Part %d: submitting %d\n", sym.submit, *day, *part, r.value)
+	verdict, err := fetch.Submit(*day, *part, r.value, *session)
+	if err != nil {
+		return err
+	}
+	if *part == 1 && verdict.Outcome == fetch.Correct {
+		// the cached statement predates part 2; drop it so puzzle shows both
+		os.Remove(puzzlePath(*day))
+	}
+	return reportVerdict(w, r.value, verdict)
+}
+
+// reportVerdict prints the site's reply and turns a rejection into an error
+func reportVerdict(w io.Writer, answer int, v fetch.Verdict) error {
+	switch v.Outcome {
+	case fetch.Correct, fetch.AlreadySolved:
+		fmt.Fprintf(w, "%s%d: %v\n", sym.star, answer, v.Outcome)
+		return nil
+	case fetch.Unknown:
+		return fmt.Errorf("%v: %s", v.Outcome, v.Message)
+	}
+
+	if v.Wait > 0 {
+		return fmt.Errorf("%d: %v; wait %v before trying again", answer, v.Outcome, v.Wait)
+	}
+	return fmt.Errorf("%d: %v", answer, v.Outcome)
+}
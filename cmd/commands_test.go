@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"adv2025/aoc/fetch"
+)
+
+func TestRegisteredDays(t *testing.T) {
+	days := registeredDays()
+	if len(days) != 12 || days[0] != 1 || days[11] != 12 {
+		t.Errorf("expected days 1 through 12, got %v", days)
+	}
+}
+
+func TestRunFetchNeedsSession(t *testing.T) {
+	t.Setenv("AOC_SESSION", "")
+	if err := runFetch(&bytes.Buffer{}, nil); err == nil || !strings.Contains(err.Error(), "session") {
+		t.Errorf("expected a missing-session error, got %v", err)
+	}
+}
+
+func TestRunFetchSkipsExistingInputs(t *testing.T) {
+	withInputs(t, 3)
+	saved := inputsDir
+	t.Cleanup(func() { inputsDir = saved })
+
+	var out bytes.Buffer
+	if err := runFetch(&out, []string{"-day", "3", "-session", "cookie"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "✔ Day 3: inputs/day3_input.txt already present") {
+		t.Errorf("expected day 3 to be left alone:\n%s", got)
+	}
+
+	if err := os.Rename("inputs", "puzzles"); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := runFetch(&out, []string{"-day", "3", "-session", "cookie", "-inputs-dir", "puzzles"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "✔ Day 3: puzzles/day3_input.txt already present") {
+		t.Errorf("expected -inputs-dir to be searched:\n%s", got)
+	}
+}
+
+func TestRunSubmitNeedsDayAndPart(t *testing.T) {
+	for _, args := range [][]string{nil, {"-day", "3"}, {"-part", "1"}} {
+		if err := runSubmit(&bytes.Buffer{}, append(args, "-session", "cookie")); err == nil {
+			t.Errorf("runSubmit(%q): expected an error", args)
+		}
+	}
+}
+
+func TestReportVerdict(t *testing.T) {
+	tests := []struct {
+		verdict fetch.Verdict
+		wantErr string // empty for success
+	}{
+		{fetch.Verdict{Outcome: fetch.Correct}, ""},
+		{fetch.Verdict{Outcome: fetch.AlreadySolved}, ""},
+		{fetch.Verdict{Outcome: fetch.TooHigh, Wait: time.Minute}, "42: too high; wait 1m0s before trying again"},
+		{fetch.Verdict{Outcome: fetch.Incorrect}, "42: wrong answer"},
+		{fetch.Verdict{Outcome: fetch.Unknown, Message: "maintenance"}, "unrecognized response: maintenance"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		err := reportVerdict(&out, 42, tt.verdict)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%v: unexpected error %v", tt.verdict.Outcome, err)
+		case tt.wantErr == "" && !strings.Contains(out.String(), "⭐ 42"):
+			t.Errorf("%v: expected a star line, got %q", tt.verdict.Outcome, out.String())
+		case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+			t.Errorf("%v: expected error %q, got %v", tt.verdict.Outcome, tt.wantErr, err)
+		}
+	}
+}
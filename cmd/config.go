@@ -0,0 +1,210 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// configNames are the config files looked for, in order, first in the
+// current directory and then in the home directory
+var configNames = []string{".aoc.yaml", ".aoc.yml", ".aoc.toml"}
+
+// config holds flag defaults read from a config file. Keys are flag names,
+// with underscores accepted for dashes; session_file names a file holding the
+// session cookie. Only flat files are supported:
+//
+//	# .aoc.yaml                # .aoc.toml
+//	timeout: 30s               timeout = "30s"
+//	parallel: 4                parallel = 4
+//	output: json               output = "json"
+//	session_file: ~/.aoc       session_file = "~/.aoc"
+type config struct {
+	path   string
+	values map[string]string
+}
+
+// findConfig returns the first config file found in dirs
+func findConfig(dirs ...string) (string, bool) {
+	for _, dir := range dirs {
+		for _, name := range configNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, true
+			}
+		}
+	}
+	return "", false
+}
+
+// loadConfig reads a .yaml/.yml (key: value) or .toml (key = value) file
+func loadConfig(path string) (config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config{}, fmt.Errorf("reading config: %w", err)
+	}
+
+	sep := ":"
+	if filepath.Ext(path) == ".toml" {
+		sep = "="
+	}
+	values, err := parseConfig(string(data), sep)
+	if err != nil {
+		return config{}, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return config{path: path, values: values}, nil
+}
+
+// parseConfig parses flat "key<sep>value" lines. Blank lines and # comments
+// are skipped and values may be quoted.
+func parseConfig(text, sep string) (map[string]string, error) {
+	values := make(map[string]string)
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			return nil, fmt.Errorf("line %d: sections are not supported", i+1)
+		}
+
+		key, value, found := strings.Cut(line, sep)
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key %s value, got %q", i+1, sep, line)
+		}
+		key = strings.ReplaceAll(strings.TrimSpace(key), "_", "-")
+		value, err := parseValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// parseValue unquotes a "double" or 'single' quoted value, or trims a bare
+// one, dropping any trailing # comment either way
+func parseValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		quoted, err := strconv.QuotedPrefix(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid string %s", value)
+		}
+		return strconv.Unquote(quoted)
+	case strings.HasPrefix(value, "'"):
+		end := strings.IndexByte(value[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string %s", value)
+		}
+		return value[1 : end+1], nil
+	}
+
+	if before, _, found := strings.Cut(value, "#"); found {
+		value = before
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// apply sets every flag in fs that the command line left alone to its config
+// value, so flags always override the file
+func (c config) apply(fs *flag.FlagSet) error {
+	onCommandLine := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { onCommandLine[f.Name] = true })
+
+	for key, value := range c.values {
+		if key == "session-file" {
+			continue
+		}
+		if fs.Lookup(key) == nil {
+			return fmt.Errorf("%s: unknown setting %q", c.path, key)
+		}
+		if onCommandLine[key] {
+			continue
+		}
+		if err := fs.Set(key, value); err != nil {
+			return fmt.Errorf("%s: %s: %w", c.path, key, err)
+		}
+	}
+
+	// The cookie file is the fallback after -session and $AOC_SESSION
+	if path, ok := c.values["session-file"]; ok {
+		if session := fs.Lookup("session"); session != nil && session.Value.String() == "" {
+			cookie, err := os.ReadFile(expandHome(path))
+			if err != nil {
+				return fmt.Errorf("%s: reading session_file: %w", c.path, err)
+			}
+			if err := fs.Set("session", strings.TrimSpace(string(cookie))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expandHome replaces a leading ~/ with the home directory
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
+
+// configFile loads the first config file in the current or home directory
+func configFile() (config, bool, error) {
+	dirs := []string{"."}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, home)
+	}
+
+	path, found := findConfig(dirs...)
+	if !found {
+		return config{}, false, nil
+	}
+	cfg, err := loadConfig(path)
+	return cfg, err == nil, err
+}
+
+// applyConfigFile applies the config file, if any, to fs
+func applyConfigFile(fs *flag.FlagSet) error {
+	cfg, found, err := configFile()
+	if !found {
+		return err
+	}
+	return cfg.apply(fs)
+}
+
+// known keeps the settings that fs has a flag for, and the session file when
+// it takes -session. Subcommands share the runner's config file, so they
+// skip its other settings rather than reject them.
+func (c config) known(fs *flag.FlagSet) config {
+	values := make(map[string]string)
+	for key, value := range c.values {
+		if fs.Lookup(key) != nil || (key == "session-file" && fs.Lookup("session") != nil) {
+			values[key] = value
+		}
+	}
+	return config{path: c.path, values: values}
+}
+
+// parseSubcommand parses a subcommand's flags, then fills the ones the
+// command line left alone from the config file, as the runner does
+func parseSubcommand(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, found, err := configFile()
+	if found {
+		err = cfg.known(fs).apply(fs)
+	}
+	inputsDir = expandHome(inputsDir) // config files get no shell expansion
+	return err
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	yaml := "# defaults\ntimeout: 30s\nparallel: 4 # cores\noutput: \"json\"\nsession_file: '~/.aoc session'\n"
+	toml := "timeout = \"30s\" # per solver\nparallel = 4\noutput = 'json'\nsession_file = \"~/.aoc session\"\n"
+
+	for name, tc := range map[string]struct{ text, sep string }{"yaml": {yaml, ":"}, "toml": {toml, "="}} {
+		values, err := parseConfig(tc.text, tc.sep)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		want := map[string]string{"timeout": "30s", "parallel": "4", "output": "json", "session-file": "~/.aoc session"}
+		for key, v := range want {
+			if values[key] != v {
+				t.Errorf("%s: %s: expected %q, got %q", name, key, v, values[key])
+			}
+		}
+		if len(values) != len(want) {
+			t.Errorf("%s: expected %d keys, got %v", name, len(want), values)
+		}
+	}
+
+	for _, bad := range []string{"[runner]", "timeout 30s", `output: "json`} {
+		if _, err := parseConfig(bad, ":"); err == nil {
+			t.Errorf("parseConfig(%q): expected an error", bad)
+		}
+	}
+}
+
+// configFlags is a flag set with the settings a config file may override
+func configFlags() (*flag.FlagSet, *time.Duration, *int, *string) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", 0, "")
+	parallel := fs.Int("parallel", 1, "")
+	session := fs.String("session", "", "")
+	return fs, timeout, parallel, session
+}
+
+func TestConfigApplyFlagsWin(t *testing.T) {
+	fs, timeout, parallel, session := configFlags()
+	if err := fs.Parse([]string{"-parallel", "8"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cookie := filepath.Join(t.TempDir(), "session")
+	if err := os.WriteFile(cookie, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config{path: ".aoc.yaml", values: map[string]string{"timeout": "30s", "parallel": "2", "session-file": cookie}}
+	if err := cfg.apply(fs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *timeout != 30*time.Second || *parallel != 8 || *session != "secret" {
+		t.Errorf("expected timeout from the file, parallel from the flag and the cookie, got %v, %d, %q",
+			*timeout, *parallel, *session)
+	}
+}
+
+func TestConfigApplyErrors(t *testing.T) {
+	for _, values := range []map[string]string{
+		{"colour": "always"},
+		{"parallel": "many"},
+		{"session-file": filepath.Join(t.TempDir(), "missing")},
+	} {
+		fs, _, _, _ := configFlags()
+		if err := (config{path: ".aoc.yaml", values: values}).apply(fs); err == nil {
+			t.Errorf("apply(%v): expected an error", values)
+		}
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	repo, home := t.TempDir(), t.TempDir()
+	if _, found := findConfig(repo, home); found {
+		t.Fatal("expected no config file")
+	}
+
+	if err := os.WriteFile(filepath.Join(home, ".aoc.toml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if path, _ := findConfig(repo, home); path != filepath.Join(home, ".aoc.toml") {
+		t.Errorf("expected the home config, got %q", path)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, ".aoc.yaml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if path, _ := findConfig(repo, home); path != filepath.Join(repo, ".aoc.yaml") {
+		t.Errorf("expected the repository config to win, got %q", path)
+	}
+}
+
+func TestParseSubcommandReadsConfig(t *testing.T) {
+	repo := t.TempDir()
+	t.Chdir(repo)
+	t.Setenv("HOME", t.TempDir())
+	saved := inputsDir
+	t.Cleanup(func() { inputsDir = saved })
+
+	cookie := filepath.Join(repo, "session")
+	if err := os.WriteFile(cookie, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// parallel and output are runner flags this subcommand lacks
+	config := "inputs_dir: puzzle-inputs\nsession_file: " + cookie + "\nparallel: 4\noutput: json\n"
+	if err := os.WriteFile(filepath.Join(repo, ".aoc.yaml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	fs.StringVar(&inputsDir, "inputs-dir", "inputs", "")
+	session := fs.String("session", "", "")
+	if err := parseSubcommand(fs, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inputsDir != "puzzle-inputs" || *session != "secret" {
+		t.Errorf("expected the config's inputs dir and cookie, got %q, %q", inputsDir, *session)
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"adv2025/aoc/fetch"
+	"adv2025/aoc/registry"
+)
+
+// runDoctor checks what the runner depends on: the inputs directory and each
+// day's input, the session cookie, and the answers file. Every problem is
+// printed with the command or edit that fixes it.
+func runDoctor(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.StringVar(&inputsDir, "inputs-dir", defaultInputsDir(), "Directory holding dayN_input.txt (default $AOC_INPUTS_DIR, then ./inputs)")
+	answersPath := fs.String("answers", "", "Known answers file (default answers.json, then answers.txt)")
+	session := fs.String("session", os.Getenv("AOC_SESSION"), "adventofcode.com session cookie (default $AOC_SESSION)")
+	offline := fs.Bool("offline", false, "Check only that a session cookie is set, without asking the site")
+	if err := parseSubcommand(fs, args); err != nil {
+		return err
+	}
+
+	d := &doctor{w: w}
+	d.checkInputs()
+	d.checkSession(*session, *offline)
+	d.checkAnswers(*answersPath)
+
+	if d.problems > 0 {
+		return fmt.Errorf("found %d problem(s)", d.problems)
+	}
+	summary := "no problems found"
+	if d.warnings > 0 {
+		summary += fmt.Sprintf(", %d warning(s)", d.warnings)
+	}
+	_, err := fmt.Fprintf(w, "\n%s%s\n", sym.ok, summary)
+	return err
+}
+
+// doctor prints one line per check. A problem stops the runner from working
+// and fails the command; a warning only limits what it can do.
+type doctor struct {
+	w                  io.Writer
+	problems, warnings int
+}
+
+func (d *doctor) ok(format string, args ...any) {
+	fmt.Fprintf(d.w, "%s%s\n", sym.ok, fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) warn(fix, format string, args ...any) {
+	d.warnings++
+	fmt.Fprintf(d.w, "%s%s\n    fix: %s\n", sym.warn, fmt.Sprintf(format, args...), fix)
+}
+
+func (d *doctor) problem(fix, format string, args ...any) {
+	d.problems++
+	fmt.Fprintf(d.w, "%s%s\n    fix: %s\n", sym.fail, fmt.Sprintf(format, args...), fix)
+}
+
+// checkInputs looks for the input of every unlocked day with a real solver;
+// days that are all stubs have not been started, so they need no input yet
+func (d *doctor) checkInputs() {
+	if info, err := os.Stat(inputsDir); err != nil || !info.IsDir() {
+		d.problem("run \"go run ./cmd fetch\" to create it and download every input, or point -inputs-dir or AOC_INPUTS_DIR at yours",
+			"inputs directory %s does not exist", inputsDir)
+		return
+	}
+	d.ok("inputs directory %s", inputsDir)
+
+	var missing []int
+	for _, day := range startedDays() {
+		if fetch.CheckUnlocked(day, now()) != nil {
+			continue
+		}
+		if _, err := os.Stat(inputPathFor(day)); err != nil {
+			missing = append(missing, day)
+		}
+	}
+	if len(missing) == 0 {
+		d.ok("every started day has its input")
+		return
+	}
+	fix := "run \"go run ./cmd fetch\" with AOC_SESSION set"
+	if len(missing) == 1 {
+		fix = fmt.Sprintf("run \"go run ./cmd fetch -day %d\" with AOC_SESSION set", missing[0])
+	}
+	d.problem(fix, "missing inputs for %s", dayList(missing))
+}
+
+// startedDays lists each day with at least one registered part that is not a stub
+func startedDays() []int {
+	var days []int
+	for _, s := range registry.All() {
+		if !s.Stub && !slices.Contains(days, s.Day) {
+			days = append(days, s.Day)
+		}
+	}
+	return days
+}
+
+const sessionFix = "log in to adventofcode.com and copy the session cookie's value into AOC_SESSION"
+
+// checkSession needs a cookie only for fetch, submit, and leaderboard, so a
+// missing one is a warning; one the site rejects is a problem
+func (d *doctor) checkSession(session string, offline bool) {
+	switch {
+	case session == "":
+		d.warn(sessionFix, "no session cookie: fetch, submit, and leaderboard will not work")
+		return
+	case offline:
+		d.ok("session cookie set (not checked with -offline)")
+		return
+	}
+
+	err := fetch.CheckSession(session)
+	switch {
+	case err == nil:
+		d.ok("session cookie accepted by adventofcode.com")
+	case errors.Is(err, fetch.ErrBadSession):
+		d.problem(sessionFix, "session cookie rejected; it has probably expired")
+	default:
+		d.warn("check your connection, or skip this check with -offline", "could not check the session cookie: %v", err)
+	}
+}
+
+// checkAnswers compares the answers file with the registered parts: an answer
+// no solver can produce is a problem, and a solved part without an answer is
+// a warning, since -verify skips it
+func (d *doctor) checkAnswers(answersPath string) {
+	path, err := findAnswers(answersPath)
+	if err != nil {
+		d.warn(`run "go run ./cmd -summary-only | grep ' = ' > answers.txt" once the answers are right`, "%v", err)
+		return
+	}
+	known, err := loadAnswers(path)
+	if err != nil {
+		d.problem("correct the file, or recreate it from a trusted run", "%v", err)
+		return
+	}
+
+	var unknown, unanswered []string
+	for day, parts := range known {
+		for part := range parts {
+			if !registry.Registered(day, part) {
+				unknown = append(unknown, fmt.Sprintf("%d.%d", day, part))
+			}
+		}
+	}
+	for _, s := range registry.All() {
+		if _, ok := known.lookup(s.Day, s.Part); !ok && !s.Stub {
+			unanswered = append(unanswered, fmt.Sprintf("%d.%d", s.Day, s.Part))
+		}
+	}
+	slices.SortFunc(unknown, compareParts)
+
+	if len(unknown) > 0 {
+		d.problem(fmt.Sprintf("remove them from %s, or add the missing solver (\"go run ./cmd new DAY\") or plugin", path),
+			"%s has answers for parts with no solver: %s", path, strings.Join(unknown, ", "))
+	}
+	if len(unanswered) > 0 {
+		d.warn(fmt.Sprintf("add each answer to %s once it is right, so -verify checks it", path),
+			"no recorded answer for %s", strings.Join(unanswered, ", "))
+	}
+	if len(unknown) == 0 && len(unanswered) == 0 {
+		d.ok("%s answers every solved part", path)
+	}
+}
+
+// compareParts orders "DAY.PART" keys numerically
+func compareParts(a, b string) int {
+	key := func(s string) (int, int) {
+		day, part, _ := strings.Cut(s, ".")
+		d, _ := strconv.Atoi(day)
+		p, _ := strconv.Atoi(part)
+		return d, p
+	}
+	ad, ap := key(a)
+	bd, bp := key(b)
+	if ad != bd {
+		return ad - bd
+	}
+	return ap - bp
+}
+
+// dayList formats days as "day 3" or "days 3, 5, 9"
+func dayList(days []int) string {
+	names := make([]string, len(days))
+	for i, day := range days {
+		names[i] = strconv.Itoa(day)
+	}
+	if len(days) == 1 {
+		return "day " + names[0]
+	}
+	return "days " + strings.Join(names, ", ")
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunDoctorReportsFixes(t *testing.T) {
+	withInputs(t, 1, 2)
+	saved := inputsDir
+	t.Cleanup(func() { inputsDir = saved })
+	if err := os.WriteFile("answers.txt", []byte("1.1 = 1147\n1.2 = 6789\n20.1 = 5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	err := runDoctor(&out, []string{"-session", "cookie", "-offline"})
+	if err == nil || err.Error() != "found 2 problem(s)" {
+		t.Errorf("expected the missing inputs and the unknown answer as problems, got %v", err)
+	}
+	for _, want := range []string{
+		"inputs directory inputs\n",
+		"missing inputs for days 3, 4, 5",
+		"session cookie set (not checked with -offline)",
+		"answers.txt has answers for parts with no solver: 20.1\n",
+		"no recorded answer for 2.1, 2.2, 3.1",
+		"    fix: run \"go run ./cmd fetch\" with AOC_SESSION set\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunDoctorMissingInputsDir(t *testing.T) {
+	t.Chdir(t.TempDir())
+	saved := inputsDir
+	t.Cleanup(func() { inputsDir = saved })
+	t.Setenv("AOC_SESSION", "")
+
+	var out bytes.Buffer
+	if err := runDoctor(&out, []string{"-inputs-dir", "nowhere"}); err == nil {
+		t.Error("expected a missing inputs directory to be a problem")
+	}
+	for _, want := range []string{"inputs directory nowhere does not exist", "no session cookie", "no answers file"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, out.String())
+		}
+	}
+}
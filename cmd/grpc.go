@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"adv2025/aoc/registry"
+	aocv1 "adv2025/proto/aoc/v1"
+)
+
+// newGRPCServer serves the aoc.v1.Solver service of proto/aoc/v1, answering
+// as serve's HTTP routes do, and logs each call to w like logRequests
+func newGRPCServer(w io.Writer, maxInput int64) *grpc.Server {
+	logCall := func(method string, start time.Time, err error) {
+		fmt.Fprintf(w, "GRPC %s %s (%s)\n", method, status.Code(err), sym.duration(time.Since(start)))
+	}
+	srv := grpc.NewServer(
+		// room for the day and part beside the largest input
+		grpc.MaxRecvMsgSize(int(maxInput)+64),
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			start := time.Now()
+			resp, err := handler(ctx, req)
+			logCall(info.FullMethod, start, err)
+			return resp, err
+		}),
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			start := time.Now()
+			err := handler(srv, ss)
+			logCall(info.FullMethod, start, err)
+			return err
+		}),
+	)
+	aocv1.RegisterSolverServer(srv, solverService{maxInput: maxInput})
+	return srv
+}
+
+// solverService implements aoc.v1.Solver over the registry
+type solverService struct {
+	aocv1.UnimplementedSolverServer
+	maxInput int64
+}
+
+func (solverService) List(context.Context, *aocv1.ListRequest) (*aocv1.ListResponse, error) {
+	resp := &aocv1.ListResponse{}
+	for _, s := range registry.All() {
+		resp.Parts = append(resp.Parts, &aocv1.Part{Day: int32(s.Day), Part: int32(s.Part), Stub: s.Stub})
+	}
+	return resp, nil
+}
+
+func (v solverService) Solve(_ context.Context, req *aocv1.SolveRequest) (*aocv1.SolveResponse, error) {
+	s, err := v.solver(req)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	answer, err := s.SolveReader(bytes.NewReader(req.GetInput()))
+	return solveResponse(answer, err, time.Since(start)), nil
+}
+
+// SolveStream sends a Progress event after each step of a part registered
+// with registry.RegisterProgress, then the result
+func (v solverService) SolveStream(req *aocv1.SolveRequest, stream grpc.ServerStreamingServer[aocv1.SolveEvent]) error {
+	s, err := v.solver(req)
+	if err != nil {
+		return err
+	}
+
+	// the solver cannot be stopped midway, so a failed send only ends the
+	// updates; the error is returned once it finishes
+	var sendErr error
+	start := time.Now()
+	answer, err := solveStreaming(s, req.GetInput(), func(done, total int) {
+		if sendErr == nil {
+			sendErr = stream.Send(&aocv1.SolveEvent{Event: &aocv1.SolveEvent_Progress{
+				Progress: &aocv1.Progress{Done: int64(done), Total: int64(total)},
+			}})
+		}
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	return stream.Send(&aocv1.SolveEvent{Event: &aocv1.SolveEvent_Result{
+		Result: solveResponse(answer, err, time.Since(start)),
+	}})
+}
+
+// solver finds the part a request asks for, with the status codes matching
+// serve's HTTP errors
+func (v solverService) solver(req *aocv1.SolveRequest) (registry.Solver, error) {
+	if int64(len(req.GetInput())) > v.maxInput {
+		return registry.Solver{}, status.Errorf(codes.ResourceExhausted, "input over %d bytes", v.maxInput)
+	}
+	s, ok := readerSolver(int(req.GetDay()), int(req.GetPart()))
+	if !ok {
+		return registry.Solver{}, status.Errorf(codes.NotFound, "no solver for day %d part %d", req.GetDay(), req.GetPart())
+	}
+	return s, nil
+}
+
+// solveResponse reports a solver's outcome; its error is part of the answer,
+// not a failed call, as with serve's 422 responses
+func solveResponse(answer int, err error, elapsed time.Duration) *aocv1.SolveResponse {
+	resp := &aocv1.SolveResponse{Answer: int64(answer), Duration: durationpb.New(elapsed)}
+	if err != nil {
+		resp.Answer, resp.Error = 0, err.Error()
+	}
+	return resp
+}
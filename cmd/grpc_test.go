@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	aocv1 "adv2025/proto/aoc/v1"
+)
+
+// grpcClient serves the Solver service in memory for the test's duration
+func grpcClient(t *testing.T, log io.Writer) aocv1.SolverClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := newGRPCServer(log, 64)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return aocv1.NewSolverClient(conn)
+}
+
+func TestGRPCList(t *testing.T) {
+	resp, err := grpcClient(t, io.Discard).List(context.Background(), &aocv1.ListRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetParts()) != len(solvers) {
+		t.Errorf("expected %d parts, got %d", len(solvers), len(resp.GetParts()))
+	}
+}
+
+func TestGRPCSolve(t *testing.T) {
+	var log bytes.Buffer
+	client := grpcClient(t, &log)
+	ctx := context.Background()
+
+	resp, err := client.Solve(ctx, &aocv1.SolveRequest{Day: 1, Part: 2, Input: []byte("L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\n")})
+	if err != nil || resp.GetAnswer() != 6 || resp.GetError() != "" {
+		t.Errorf("expected day 1 part 2 = 6, got %v, %v", resp, err)
+	}
+	if !strings.Contains(log.String(), "/aoc.v1.Solver/Solve OK") {
+		t.Errorf("expected the call logged, got %q", log.String())
+	}
+
+	if resp, err := client.Solve(ctx, &aocv1.SolveRequest{Day: 1, Part: 1, Input: []byte("X5\n")}); err != nil || resp.GetError() == "" {
+		t.Errorf("expected the solver's error in the response, got %v, %v", resp, err)
+	}
+
+	for _, tt := range []struct {
+		req  *aocv1.SolveRequest
+		want codes.Code
+	}{
+		{&aocv1.SolveRequest{Day: 30, Part: 1}, codes.NotFound},
+		{&aocv1.SolveRequest{Day: 1, Part: 1, Input: []byte(strings.Repeat("L1\n", 100))}, codes.ResourceExhausted},
+	} {
+		if _, err := client.Solve(ctx, tt.req); status.Code(err) != tt.want {
+			t.Errorf("day %d part %d: expected %v, got %v", tt.req.GetDay(), tt.req.GetPart(), tt.want, err)
+		}
+	}
+}
+
+func TestGRPCSolveStream(t *testing.T) {
+	stream, err := grpcClient(t, io.Discard).SolveStream(context.Background(), &aocv1.SolveRequest{Day: 2, Part: 2, Input: []byte("11-22,95-115,998-1012\n")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []int64
+	var result *aocv1.SolveResponse
+	for {
+		ev, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p := ev.GetProgress(); p != nil {
+			progress = append(progress, p.GetDone())
+		}
+		if r := ev.GetResult(); r != nil {
+			result = r
+		}
+	}
+
+	if len(progress) != 3 || progress[2] != 3 {
+		t.Errorf("expected a progress event per range, got %v", progress)
+	}
+	// 11 + 22 + 99 + 111 + 999 + 1010
+	if result.GetAnswer() != 2252 || result.GetError() != "" {
+		t.Errorf("expected the answer 2252 last, got %v", result)
+	}
+}
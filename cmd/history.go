@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// historyPath is the JSONL file each run appends its timings to
+var historyPath = filepath.Join(".aoc", "history", "timings.jsonl")
+
+// minRegression ignores slowdowns smaller than this, however large in
+// percent: sub-millisecond solvers jitter by more than any useful threshold
+const minRegression = 100 * time.Microsecond
+
+// otherInputFlag names the flag, if any, that points the run away from the
+// puzzle inputs. The history only keeps timings of the puzzle inputs, which
+// examples and other inputs would make meaningless to compare against.
+func otherInputFlag(example bool, set string, overridden bool) string {
+	switch {
+	case example:
+		return "-example"
+	case set != "":
+		return "-set"
+	case overridden:
+		return "-input"
+	}
+	return ""
+}
+
+// historyEntry is one part's timing within a run
+type historyEntry struct {
+	Day        int   `json:"day"`
+	Part       int   `json:"part"`
+	DurationNS int64 `json:"duration_ns"`
+}
+
+// historyRun is one line of the history file
+type historyRun struct {
+	Time    time.Time      `json:"time"`
+	Results []historyEntry `json:"results"`
+}
+
+// regression is a part that got slower than its baseline by more than the threshold
+type regression struct {
+	day, part int
+	was, now  time.Duration
+}
+
+func (r regression) percent() float64 {
+	return 100 * float64(r.now-r.was) / float64(r.was)
+}
+
+// historyReporter passes results through to the real reporter and keeps the
+// timings of the ones that succeeded, and actually ran, for the history file
+type historyReporter struct {
+	Reporter
+	entries []historyEntry
+}
+
+func (h *historyReporter) Result(r result) {
+	if r.err == nil && !r.cached {
+		h.entries = append(h.entries, historyEntry{r.day, r.part, r.elapsed.Nanoseconds()})
+	}
+	h.Reporter.Result(r)
+}
+
+// loadHistory reads every recorded run, oldest first. A missing file is an
+// empty history.
+func loadHistory(path string) ([]historyRun, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading timing history: %w", err)
+	}
+	defer f.Close()
+
+	var runs []historyRun
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var run historyRun
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, scanner.Err()
+}
+
+// appendHistory adds run to the end of the history file, creating it if needed
+func appendHistory(path string, run historyRun) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating history directory: %w", err)
+	}
+	line, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening timing history: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing timing history: %w", err)
+	}
+	return f.Close()
+}
+
+// baseline maps each part to its time in the most recent run that timed it,
+// so a run of one day compares against that day's last run rather than
+// whatever ran last
+func baseline(runs []historyRun) map[[2]int]time.Duration {
+	latest := make(map[[2]int]time.Duration)
+	for _, run := range runs {
+		for _, e := range run.Results {
+			latest[[2]int{e.Day, e.Part}] = time.Duration(e.DurationNS)
+		}
+	}
+	return latest
+}
+
+// regressions lists the entries more than threshold percent slower than base
+func regressions(base map[[2]int]time.Duration, entries []historyEntry, threshold float64) []regression {
+	var slower []regression
+	for _, e := range entries {
+		was, ok := base[[2]int{e.Day, e.Part}]
+		now := time.Duration(e.DurationNS)
+		if !ok || was <= 0 || now-was < minRegression {
+			continue
+		}
+		if r := (regression{e.Day, e.Part, was, now}); r.percent() > threshold {
+			slower = append(slower, r)
+		}
+	}
+	return slower
+}
+
+// recordHistory appends this run's timings to the history file and, when
+// threshold is positive, reports to w every part that regressed by more than
+// threshold percent against the previous runs. It returns whether any did.
+func recordHistory(w io.Writer, path string, entries []historyEntry, threshold float64) (bool, error) {
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	var slower []regression
+	if threshold > 0 {
+		runs, err := loadHistory(path)
+		if err != nil {
+			return false, err
+		}
+		slower = regressions(baseline(runs), entries, threshold)
+		for _, r := range slower {
+			fmt.Fprintf(w, "%sDay %d Part %d regressed %.0f%%: %v -> %v\n",
+				sym.warn, r.day, r.part, r.percent(), sym.duration(r.was), sym.duration(r.now))
+		}
+	}
+
+	return len(slower) > 0, appendHistory(path, historyRun{Time: time.Now().UTC(), Results: entries})
+}
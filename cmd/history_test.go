@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".aoc", "history", "timings.jsonl")
+	ms := time.Millisecond.Nanoseconds()
+
+	var out bytes.Buffer
+	first := []historyEntry{{1, 1, 10 * ms}, {1, 2, 10 * ms}, {2, 1, 10 * ms}}
+	if regressed, err := recordHistory(&out, path, first, 20); err != nil || regressed {
+		t.Fatalf("a first run has nothing to regress against, got %v, %v", regressed, err)
+	}
+
+	// day 2 only: day 1 keeps its baseline from the first run
+	if _, err := recordHistory(&out, path, []historyEntry{{2, 1, 11 * ms}}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	third := []historyEntry{{1, 1, 15 * ms}, {1, 2, 11 * ms}, {2, 1, 20 * ms}}
+	regressed, err := recordHistory(&out, path, third, 20)
+	if err != nil || !regressed {
+		t.Fatalf("expected a regression, got %v, %v", regressed, err)
+	}
+	report := out.String()
+	for _, want := range []string{"Day 1 Part 1 regressed 50%", "Day 2 Part 1 regressed 82%"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected %q in:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "Day 1 Part 2") {
+		t.Errorf("a 10%% slowdown is under the threshold:\n%s", report)
+	}
+
+	runs, err := loadHistory(path)
+	if err != nil || len(runs) != 3 {
+		t.Fatalf("expected 3 recorded runs, got %d, %v", len(runs), err)
+	}
+}
+
+func TestRegressionsIgnoresJitter(t *testing.T) {
+	base := map[[2]int]time.Duration{{3, 1}: 20 * time.Microsecond}
+	entries := []historyEntry{{3, 1, (60 * time.Microsecond).Nanoseconds()}, {4, 1, 1}}
+	if got := regressions(base, entries, 10); len(got) != 0 {
+		t.Errorf("expected tiny and unrecorded parts to be ignored, got %v", got)
+	}
+}
+
+func TestHistoryReporterSkipsErrors(t *testing.T) {
+	h := &historyReporter{Reporter: &textReporter{w: &bytes.Buffer{}}}
+	h.Result(result{day: 1, part: 1, elapsed: time.Second})
+	h.Result(result{day: 1, part: 2, err: errors.New("boom")})
+	if len(h.entries) != 1 || h.entries[0].Part != 1 {
+		t.Errorf("expected only the successful part recorded, got %v", h.entries)
+	}
+}
+
+func TestLoadHistoryMissing(t *testing.T) {
+	runs, err := loadHistory(filepath.Join(t.TempDir(), "none.jsonl"))
+	if err != nil || runs != nil {
+		t.Errorf("a missing history should be empty, got %v, %v", runs, err)
+	}
+}
+
+func TestOtherInputFlag(t *testing.T) {
+	if got := otherInputFlag(false, "", false); got != "" {
+		t.Errorf("expected the puzzle inputs to be recorded, got %q", got)
+	}
+	for _, tt := range []struct {
+		example    bool
+		set        string
+		overridden bool
+		want       string
+	}{
+		{true, "", false, "-example"},
+		{false, "small", false, "-set"},
+		{false, "", true, "-input"},
+	} {
+		if got := otherInputFlag(tt.example, tt.set, tt.overridden); got != tt.want {
+			t.Errorf("expected %s to skip the history, got %q", tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// inputKey selects the solvers an -input override applies to; part 0 covers
+// every part of the day
+type inputKey struct {
+	day, part int
+}
+
+// inputFlag collects -input values. Each is either a bare PATH, applied to the
+// solvers picked by -day/-part, or DAY=PATH / DAY.PART=PATH for a specific day
+// or part. The flag may be repeated.
+type inputFlag struct {
+	path      string
+	overrides map[inputKey]string
+}
+
+// inputOverrides holds the parsed -input flag consulted by inputPath
+var inputOverrides inputFlag
+
+func (f *inputFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	var values []string
+	if f.path != "" {
+		values = append(values, f.path)
+	}
+	for key, path := range f.overrides {
+		values = append(values, fmt.Sprintf("%s=%s", key, path))
+	}
+	return strings.Join(values, ",")
+}
+
+func (f *inputFlag) Set(value string) error {
+	if value == "" {
+		return fmt.Errorf("empty input path")
+	}
+
+	if spec, path, found := strings.Cut(value, "="); found {
+		if key, err := parseInputKey(spec); err == nil {
+			if path == "" {
+				return fmt.Errorf("empty input path for day %s", spec)
+			}
+			f.set(key, path)
+			return nil
+		}
+	}
+
+	if f.path != "" {
+		return fmt.Errorf("more than one bare input path; use DAY=PATH to override several days")
+	}
+	f.path = value
+	return nil
+}
+
+func (f *inputFlag) set(key inputKey, path string) {
+	if f.overrides == nil {
+		f.overrides = make(map[inputKey]string)
+	}
+	f.overrides[key] = path
+}
+
+func (k inputKey) String() string {
+	if k.part == 0 {
+		return strconv.Itoa(k.day)
+	}
+	return fmt.Sprintf("%d.%d", k.day, k.part)
+}
+
+// parseInputKey parses DAY or DAY.PART
+func parseInputKey(spec string) (inputKey, error) {
+	daySpec, partSpec, hasPart := strings.Cut(spec, ".")
+
+	day, err := strconv.Atoi(daySpec)
+	if err != nil || day < 1 {
+		return inputKey{}, fmt.Errorf("invalid day %q", daySpec)
+	}
+	if !hasPart {
+		return inputKey{day: day}, nil
+	}
+
+	part, err := strconv.Atoi(partSpec)
+	if err != nil || part < 1 {
+		return inputKey{}, fmt.Errorf("invalid part %q", partSpec)
+	}
+	return inputKey{day: day, part: part}, nil
+}
+
+// resolve binds a bare -input path to the selected day and part
+func (f *inputFlag) resolve(day, part int) error {
+	if f.path == "" {
+		return nil
+	}
+	if day == 0 {
+		return fmt.Errorf("-input %s needs a specific -day (or use -input DAY=PATH)", f.path)
+	}
+	f.set(inputKey{day, part}, f.path)
+	f.path = ""
+	return nil
+}
+
+// lookup returns the override for day and part, preferring a part-specific one
+func (f *inputFlag) lookup(day, part int) (string, bool) {
+	if path, ok := f.overrides[inputKey{day, part}]; ok {
+		return path, true
+	}
+	path, ok := f.overrides[inputKey{day: day}]
+	return path, ok
+}
+
+// useExamples is set by -example to read puzzle examples instead of inputs
+var useExamples bool
+
+// inputPath returns the file a solver reads: its -input override, the day's
+// example under -example, or the day's input under -inputs-dir
+func inputPath(day, part int) string {
+	if path, ok := inputOverrides.lookup(day, part); ok {
+		return path
+	}
+	if useExamples {
+		return examplePathFor(day)
+	}
+	return inputPathFor(day)
+}
+
+// examplePathFor returns the day's example input: dayN_example.txt next to
+// the real inputs, or else the day package's testdata/example.txt. When
+// neither exists the first is returned, so the error names where to put it.
+func examplePathFor(day int) string {
+	candidates := []string{
+		filepath.Join(inputsDir, fmt.Sprintf("day%d_example.txt", day)),
+		filepath.Join("aoc", fmt.Sprintf("day%d", day), "testdata", "example.txt"),
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return candidates[0]
+}
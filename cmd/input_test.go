@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// setInputs installs -input values for the duration of the test
+func setInputs(t *testing.T, day, part int, values ...string) {
+	t.Helper()
+
+	saved := inputOverrides
+	t.Cleanup(func() { inputOverrides = saved })
+
+	inputOverrides = inputFlag{}
+	for _, v := range values {
+		if err := inputOverrides.Set(v); err != nil {
+			t.Fatalf("Set(%q): %v", v, err)
+		}
+	}
+	if err := inputOverrides.resolve(day, part); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+}
+
+func TestInputPath(t *testing.T) {
+	setInputs(t, 0, 0, "3=example.txt", "3.2=other.txt", "5=a=b.txt")
+
+	tests := []struct {
+		day, part int
+		want      string
+	}{
+		{3, 1, "example.txt"},
+		{3, 2, "other.txt"},
+		{5, 1, "a=b.txt"},
+		{4, 1, inputPathFor(4)},
+	}
+	for _, tt := range tests {
+		if got := inputPath(tt.day, tt.part); got != tt.want {
+			t.Errorf("inputPath(%d, %d): expected %q, got %q", tt.day, tt.part, tt.want, got)
+		}
+	}
+}
+
+func TestInputPathBare(t *testing.T) {
+	setInputs(t, 2, 1, "sample.txt")
+
+	if got := inputPath(2, 1); got != "sample.txt" {
+		t.Errorf("expected the bare path for day 2 part 1, got %q", got)
+	}
+	if got := inputPath(2, 2); got != inputPathFor(2) {
+		t.Errorf("a bare path for -part 1 should not apply to part 2, got %q", got)
+	}
+}
+
+func TestInputFlagErrors(t *testing.T) {
+	var f inputFlag
+	if err := f.resolve(0, 0); err != nil {
+		t.Errorf("resolving without a bare path: %v", err)
+	}
+	for _, bad := range []string{"", "3="} {
+		if err := f.Set(bad); err == nil {
+			t.Errorf("Set(%q): expected an error", bad)
+		}
+	}
+
+	if err := f.Set("sample.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Set("other.txt"); err == nil {
+		t.Error("expected an error for a second bare path")
+	}
+	if err := f.resolve(0, 0); err == nil {
+		t.Error("expected a bare path without -day to be rejected")
+	}
+}
+
+func TestRunSolverUsesInputOverride(t *testing.T) {
+	withInputs(t) // no default input files
+	if err := os.WriteFile("example.txt", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	setInputs(t, 0, 0, "2=example.txt")
+
+	var gotPath string
+	r := runSolver(solver{day: 2, part: 1, solve: func(path string) (int, error) {
+		gotPath = path
+		return 7, nil
+	}})
+	if r.err != nil || r.value != 7 || gotPath != "example.txt" {
+		t.Errorf("expected day 2 to read example.txt, got %d, %v from %q", r.value, r.err, gotPath)
+	}
+
+	if r := runSolver(solver{day: 3, part: 1, solve: fixed(1)}); !errors.Is(r.err, errInputNotFound) {
+		t.Errorf("expected day 3 to still need its own input, got %v", r.err)
+	}
+}
+
+func TestInputsDir(t *testing.T) {
+	saved := inputsDir
+	t.Cleanup(func() { inputsDir = saved })
+
+	t.Setenv("AOC_INPUTS_DIR", "")
+	if got := defaultInputsDir(); got != "inputs" {
+		t.Errorf("expected ./inputs by default, got %q", got)
+	}
+	t.Setenv("AOC_INPUTS_DIR", "/srv/aoc")
+	if got := defaultInputsDir(); got != "/srv/aoc" {
+		t.Errorf("expected $AOC_INPUTS_DIR, got %q", got)
+	}
+
+	inputsDir = "/srv/aoc"
+	if got := inputPath(7, 1); got != "/srv/aoc/day7_input.txt" {
+		t.Errorf("expected the input under -inputs-dir, got %q", got)
+	}
+}
+
+func TestExamplePath(t *testing.T) {
+	withInputs(t)
+	useExamples = true
+	t.Cleanup(func() { useExamples = false })
+
+	if got := inputPath(3, 1); got != "inputs/day3_example.txt" {
+		t.Errorf("with no example anywhere, expected the inputs/ location, got %q", got)
+	}
+
+	if err := os.MkdirAll("aoc/day3/testdata", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("aoc/day3/testdata/example.txt", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := inputPath(3, 1); got != "aoc/day3/testdata/example.txt" {
+		t.Errorf("expected the package testdata example, got %q", got)
+	}
+
+	if err := os.WriteFile("inputs/day3_example.txt", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := inputPath(3, 1); got != "inputs/day3_example.txt" {
+		t.Errorf("expected inputs/day3_example.txt to win, got %q", got)
+	}
+
+	setInputs(t, 0, 0, "3=mine.txt")
+	if got := inputPath(3, 1); got != "mine.txt" {
+		t.Errorf("expected -input to override -example, got %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"adv2025/aoc/fetch"
+)
+
+// runLeaderboard prints a private leaderboard: each member's rank, local
+// score, stars, and a star map of the event's days
+func runLeaderboard(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("leaderboard", flag.ContinueOnError)
+	id := fs.String("id", os.Getenv("AOC_LEADERBOARD"), "Private leaderboard ID, the number in its URL (default $AOC_LEADERBOARD)")
+	session := fs.String("session", os.Getenv("AOC_SESSION"), "adventofcode.com session cookie (default $AOC_SESSION)")
+	if err := parseSubcommand(fs, args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return errors.New("leaderboard needs an ID: set AOC_LEADERBOARD or pass -id")
+	}
+	if *session == "" {
+		return errors.New("leaderboard needs a session cookie: set AOC_SESSION or pass -session")
+	}
+	if err := fetch.CheckLeaderboardID(*id); err != nil {
+		return err
+	}
+
+	board, err := fetch.EnsureLeaderboard(leaderboardCachePath(*id), *id, *session)
+	if err != nil {
+		return err
+	}
+	return printLeaderboard(w, board)
+}
+
+// leaderboardCachePath keeps each leaderboard next to the answer cache; id
+// must have passed fetch.CheckLeaderboardID
+func leaderboardCachePath(id string) string {
+	return filepath.Join(filepath.Dir(cachePath), "leaderboard-"+id+".json")
+}
+
+func printLeaderboard(w io.Writer, board *fetch.Leaderboard) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "RANK\tSCORE\tSTARS\t%s\tNAME\n", dayRuler())
+	for i, m := range board.Ranked() {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\n", i+1, m.LocalScore, m.Stars, starMap(m), m.DisplayName())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, "\n* both stars, + first star only, . not started")
+	return err
+}
+
+// dayRuler labels the star map's columns with the last digit of each day
+func dayRuler() string {
+	var b strings.Builder
+	for day := 1; day <= fetch.Days; day++ {
+		b.WriteByte(byte('0' + day%10))
+	}
+	return b.String()
+}
+
+// starMap renders one character per day: * for both stars, + for one
+func starMap(m fetch.Member) string {
+	var b strings.Builder
+	for day := 1; day <= fetch.Days; day++ {
+		b.WriteByte(".+*"[min(m.StarsOn(day), 2)])
+	}
+	return b.String()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"adv2025/aoc/fetch"
+)
+
+func TestPrintLeaderboard(t *testing.T) {
+	var board fetch.Leaderboard
+	err := json.Unmarshal([]byte(`{"members":{
+		"7":{"id":7,"name":"ada","stars":3,"local_score":9,
+		     "completion_day_level":{"1":{"1":{},"2":{}},"3":{"1":{}}}},
+		"8":{"id":8,"stars":0,"local_score":0}}}`), &board)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := printLeaderboard(&out, &board); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(out.String(), "\n")
+	if !strings.HasPrefix(lines[0], "RANK") || !strings.Contains(lines[0], "123456789012") {
+		t.Errorf("expected a header with a day ruler, got %q", lines[0])
+	}
+	for i, want := range []string{"1     9      3      *.+.........  ada", "2     0      0      ............  (anonymous user #8)"} {
+		if lines[i+1] != want {
+			t.Errorf("row %d: expected %q, got %q", i+1, want, lines[i+1])
+		}
+	}
+}
+
+func TestRunLeaderboardNeedsIDAndSession(t *testing.T) {
+	t.Setenv("AOC_LEADERBOARD", "")
+	t.Setenv("AOC_SESSION", "")
+	if err := runLeaderboard(&bytes.Buffer{}, []string{"-session", "cookie"}); err == nil || !strings.Contains(err.Error(), "ID") {
+		t.Errorf("expected a missing-ID error, got %v", err)
+	}
+	if err := runLeaderboard(&bytes.Buffer{}, []string{"-id", "1"}); err == nil || !strings.Contains(err.Error(), "session") {
+		t.Errorf("expected a missing-session error, got %v", err)
+	}
+}
+
+func TestRunLeaderboardRejectsPathIDs(t *testing.T) {
+	t.Chdir(t.TempDir())
+	err := runLeaderboard(&bytes.Buffer{}, []string{"-id", "../x", "-session", "cookie"})
+	if !errors.Is(err, fetch.ErrBadLeaderboardID) {
+		t.Errorf("expected ErrBadLeaderboardID, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"adv2025/aoc/registry"
+)
+
+// runList prints a table of every registered part: whether it is solved or
+// still a stub, whether its input is on disk, its recorded answer and its
+// latest timing in the history
+func runList(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.StringVar(&inputsDir, "inputs-dir", defaultInputsDir(), "Directory holding dayN_input.txt (default $AOC_INPUTS_DIR, then ./inputs)")
+	answersPath := fs.String("answers", "", "Known answers file (default answers.json, then answers.txt)")
+	if err := parseSubcommand(fs, args); err != nil {
+		return err
+	}
+
+	// A missing answers file just leaves the column empty
+	var known answers
+	if path, err := findAnswers(*answersPath); err == nil {
+		if known, err = loadAnswers(path); err != nil {
+			return err
+		}
+	}
+
+	// so does a missing history file
+	runs, err := loadHistory(historyPath)
+	if err != nil {
+		return err
+	}
+	last := baseline(runs)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tSTATUS\tINPUT\tANSWER\tLAST")
+	for _, s := range registry.All() {
+		status := "solved"
+		if s.Stub {
+			status = "stub"
+		}
+
+		input := "present"
+		if _, err := os.Stat(inputPath(s.Day, s.Part)); err != nil {
+			input = "missing"
+		}
+
+		answer := "-"
+		if want, ok := known.lookup(s.Day, s.Part); ok {
+			answer = strconv.Itoa(want)
+		}
+
+		timing := "-"
+		if d, ok := last[[2]int{s.Day, s.Part}]; ok {
+			timing = sym.duration(d)
+		}
+
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\n", s.Day, s.Part, status, input, answer, timing)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunList(t *testing.T) {
+	withInputs(t, 1)
+	saved := inputsDir
+	t.Cleanup(func() { inputsDir = saved })
+	if err := os.WriteFile("answers.txt", []byte("1.1 = 1147\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, run := range []historyRun{
+		{Results: []historyEntry{{Day: 1, Part: 1, DurationNS: int64(3 * time.Millisecond)}}},
+		{Results: []historyEntry{{Day: 1, Part: 1, DurationNS: int64(2 * time.Millisecond)}}},
+	} {
+		if err := appendHistory(historyPath, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runList(&out, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n")[1:] {
+		fields := strings.Fields(line)
+		rows[fields[0]+"."+fields[1]] = strings.Join(fields[2:], " ")
+	}
+	if len(rows) != len(solvers) {
+		t.Fatalf("expected a row per registered part, got %d:\n%s", len(rows), out.String())
+	}
+	for part, want := range map[string]string{
+		"1.1": "solved present 1147 2ms",
+		"1.2": "solved present - -",
+		"2.1": "solved missing - -",
+		"7.1": "stub missing - -",
+	} {
+		if rows[part] != want {
+			t.Errorf("%s: expected %q, got %q", part, want, rows[part])
+		}
+	}
+}
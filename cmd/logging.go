@@ -0,0 +1,23 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+)
+
+// setupLogging points the shared slog logger, which the day packages log
+// through, at w: -verbose shows the runner's Info events and -debug adds the
+// day packages' Debug events. Without either the default logger stays in
+// place, so log.Printf warnings keep their plain format, and only slog
+// warnings and errors get through.
+func setupLogging(w io.Writer, verbose, debug bool) {
+	level := slog.LevelInfo
+	switch {
+	case debug:
+		level = slog.LevelDebug
+	case !verbose:
+		slog.SetLogLoggerLevel(slog.LevelWarn)
+		return
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})))
+}
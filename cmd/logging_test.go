@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"adv2025/aoc/day4"
+)
+
+func TestSetupLogging(t *testing.T) {
+	saved := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(saved) })
+
+	var out bytes.Buffer
+	setupLogging(&out, true, false)
+	slog.Info("shown")
+	slog.Debug("hidden")
+	if got := out.String(); !strings.Contains(got, "msg=shown") || strings.Contains(got, "hidden") {
+		t.Errorf("-verbose should log Info but not Debug:\n%s", got)
+	}
+
+	out.Reset()
+	setupLogging(&out, false, true)
+	if _, err := day4.Part2FromReader(strings.NewReader("@@@\n@@@\n@@@\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.Contains(got, `msg="day4: removal round" round=1`) {
+		t.Errorf("-debug should show the day packages' logs:\n%s", got)
+	}
+}
+
+func TestSetupLoggingDefault(t *testing.T) {
+	saved := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(saved) })
+
+	t.Cleanup(func() { slog.SetLogLoggerLevel(slog.LevelInfo) })
+
+	setupLogging(&bytes.Buffer{}, false, false)
+	if slog.Default() != saved {
+		t.Error("without -verbose or -debug the default logger should stay in place")
+	}
+	if slog.Default().Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("without -verbose the runner's Info events should be hidden")
+	}
+}
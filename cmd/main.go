@@ -1,75 +1,516 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"adv2025/aoc/fetch"
+	"adv2025/aoc/plugin"
+	"adv2025/aoc/registry"
+
+	// Each day registers its parts with aoc/registry from its init function
 	day1 "adv2025/aoc/day1"
-	day2 "adv2025/aoc/day2"
-	day3 "adv2025/aoc/day3"
-	day4 "adv2025/aoc/day4"
-	day5 "adv2025/aoc/day5"
-	day6 "adv2025/aoc/day6"
-	day7 "adv2025/aoc/day7"
-	day8 "adv2025/aoc/day8"
-	day9 "adv2025/aoc/day9"
-	day10 "adv2025/aoc/day10"
-	day11 "adv2025/aoc/day11"
-	day12 "adv2025/aoc/day12"
+	_ "adv2025/aoc/day10"
+	_ "adv2025/aoc/day11"
+	_ "adv2025/aoc/day12"
+	_ "adv2025/aoc/day2"
+	_ "adv2025/aoc/day3"
+	_ "adv2025/aoc/day4"
+	_ "adv2025/aoc/day5"
+	_ "adv2025/aoc/day6"
+	_ "adv2025/aoc/day7"
+	_ "adv2025/aoc/day8"
+	_ "adv2025/aoc/day9"
+)
+
+// Exit codes, so scripts can tell failures apart without parsing the output.
+// Invalid flags exit with 2, as the flag package does.
+const (
+	exitUsage       = 1 // bad arguments, config, or answers file (log.Fatal)
+	exitNoSolvers   = 3 // -day/-part matched no registered solver
+	exitSolverError = 4 // a solver failed, timed out, went over -max-time, or had no input
+	exitWrongAnswer = 5 // -verify found an answer that does not match
+	exitRegression  = 6 // -compare-baseline found a part slower than its last run
 )
 
 type solver struct {
 	day   int
 	part  int
 	solve func(string) (int, error)
+
+	// wrapped is set once solve is no longer the registry's own function, so
+	// its context and progress variants must not replace it
+	wrapped bool
+
+	// variant names what besides the input decides the answer, such as the
+	// -start dial or a plugin's build, so the cache keeps their answers apart
+	variant string
+}
+
+// wrap returns s running solve instead, keeping its day, part, and variant
+func (s solver) wrap(solve func(string) (int, error)) solver {
+	s.solve, s.wrapped = solve, true
+	return s
 }
 
+// solvers lists every registered part, sorted by day and part
 var solvers []solver
 
-func register(day int, parts ...func(string) (int, error)) {
-	for i, part := range parts {
-		solvers = append(solvers, solver{day, i + 1, part})
+func init() {
+	collectSolvers()
+}
+
+// collectSolvers rebuilds solvers from the registry, e.g. after plugins
+// registered more parts. A plugin part's variant is its executable's hash, so
+// rebuilding the plugin invalidates its cached answers.
+func collectSolvers() {
+	solvers = nil
+	for _, s := range registry.All() {
+		variant := ""
+		if path, ok := plugin.Source(s.Day, s.Part); ok {
+			// an unreadable plugin cannot answer, so nothing is cached under its path
+			variant = "plugin:" + path
+			if hash, err := fileHash(path); err == nil {
+				variant = "plugin:" + hash
+			}
+		}
+		solvers = append(solvers, solver{day: s.Day, part: s.Part, solve: s.Solve, variant: variant})
 	}
 }
 
-func init() {
-	register(1, day1.Parts...)
-	register(2, day2.Parts...)
-	register(3, day3.Parts...)
-	register(4, day4.Parts...)
-	register(5, day5.Parts...)
-	register(6, day6.Parts...)
-	register(7, day7.Parts...)
-	register(8, day8.Parts...)
-	register(9, day9.Parts...)
-	register(10, day10.Parts...)
-	register(11, day11.Parts...)
-	register(12, day12.Parts...)
+// pluginsDir holds external solver executables ($AOC_PLUGINS_DIR, then ./plugins)
+func pluginsDir() string {
+	if dir := os.Getenv("AOC_PLUGINS_DIR"); dir != "" {
+		return dir
+	}
+	return "plugins"
+}
+
+// loadPlugins registers the solvers in pluginsDir next to the built-in days.
+// A broken plugin is only a warning: the built-in days still run.
+func loadPlugins() {
+	if err := plugin.Load(pluginsDir()); err != nil {
+		log.Printf("%s%v", sym.warn, err)
+	}
+	collectSolvers()
 }
 
 func main() {
+	if defaultPlain() {
+		sym = plainSymbols
+	}
+	loadPlugins()
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			// subcommands that solve (badge, submit) keep the runner's Info logs quiet
+			setupLogging(os.Stderr, false, false)
+			if err := command(os.Stdout, os.Args[2:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+				log.Fatalf("%s: %v", os.Args[1], err)
+			}
+			return
+		}
+	}
+
 	day := flag.Int("day", 0, "Day to run (0 for all)")
 	part := flag.Int("part", 0, "Part to run (0 for all parts of the day)")
+	bench := flag.Int("bench", 0, "Run each solver N times and report timing statistics")
+	flag.BoolVar(&measureMemory, "mem", false, "Report each solver's allocations and peak heap alongside its time")
+	flag.IntVar(&repeat, "repeat", 1, "Run each solver N times and report its p50/p90/p99 time alongside the answer")
+	warmup := flag.Int("warmup", 1, "With -bench, untimed runs of each solver before measuring")
+	benchFormat := flag.String("bench-format", "text", "With -bench, text for the statistics table or go for benchstat-compatible BenchmarkDayNPartM lines")
+	summaryOnly := flag.Bool("summary-only", false, "Print only a final table of answers and the total time")
+	eval := flag.String("eval", "", `Solve an inline day 1 program, e.g. "L68,R48,L7"`)
+	start := flag.Int("start", int(day1.StartPosition), "Starting dial position for day 1 (requires -day 1)")
+	session := flag.String("session", os.Getenv("AOC_SESSION"), "adventofcode.com session cookie for downloading missing inputs (default $AOC_SESSION)")
+	output := flag.String("output", "text", "Output format: text, json, csv, or tsv")
+	parallel := flag.Int("parallel", 1, "Run up to N solvers at once; output stays in day/part order")
+	flag.DurationVar(&maxTime, "max-time", 0, "Fail any part that takes longer than this, e.g. 1s, without stopping it (0 for no budget)")
+	timeout := flag.Duration("timeout", 0, "Give up on a solver after this long and report it as timed out, e.g. 30s (0 for no limit)")
+	cpuProfile := flag.String("cpuprofile", "", "Write a CPU profile per day/part, e.g. cpu.pprof becomes cpu.day2.part1.pprof")
+	memProfile := flag.String("memprofile", "", "Write a heap profile per day/part, named like -cpuprofile")
+	showProgress := flag.Bool("progress", isTerminal(os.Stderr), "Show a live progress line for solvers running over 2s (default on when stderr is a terminal)")
+	watch := flag.Bool("watch", false, "Re-run the selected day whenever its Go files or input change (requires -day)")
+	plain := flag.Bool("plain", defaultPlain(), "ASCII-only output without emoji or a progress line (default on when NO_COLOR is set)")
+	outPath := flag.String("out", "", "Write the results to `FILE` instead of stdout")
+	verify := flag.Bool("verify", false, "Check each result against the known answers file")
+	strict := flag.Bool("strict", false, "With -verify, fail solvers that have no recorded answer")
+	answersPath := flag.String("answers", "", "Known answers file used by -verify (default answers.json, then answers.txt)")
+	stdin := flag.Bool("stdin", false, "Read the puzzle input from stdin instead of inputs/ (requires -day)")
+	inputString := flag.String("input-string", "", `Solve this text instead of an input file (requires -day); \n and \t are expanded, e.g. "L68\nR48"`)
+	flag.StringVar(&inputsDir, "inputs-dir", defaultInputsDir(), "Directory holding dayN_input.txt (default $AOC_INPUTS_DIR, then ./inputs)")
+	flag.BoolVar(&useExamples, "example", false, "Run against dayN_example.txt in -inputs-dir (or aoc/dayN/testdata/example.txt); -verify then reads example_answers.json/txt")
+	setName := flag.String("set", "", "Run the named input set of each day from inputs/sets.json; -verify uses the set's answers")
+	flag.Var(&inputOverrides, "input", "Read the input from `PATH` instead of -inputs-dir (requires -day); DAY=PATH or DAY.PART=PATH overrides one day or part, repeatable")
+	quiet := flag.Bool("quiet", false, "Print only the answers, one per line (errors go to stderr)")
+	flag.BoolVar(quiet, "q", false, "Shorthand for -quiet")
+	verbose := flag.Bool("verbose", false, "Log what the runner is doing (inputs, cache hits) to stderr")
+	debug := flag.Bool("debug", false, "Like -verbose, plus the day packages' debug logs (lines parsed, rounds simulated, ...)")
+	noHistory := flag.Bool("no-history", false, "Do not append this run's timings to .aoc/history/timings.jsonl")
+	force := flag.Bool("force", false, "Run or fetch a -day that has not unlocked yet")
+	noCache := flag.Bool("no-cache", false, "Re-run every solver instead of reusing answers cached for unchanged code and input")
+	compareBaseline := flag.Float64("compare-baseline", 0, "Flag every part more than `N` percent slower than its last recorded run (exits 6)")
 	flag.Parse()
 
+	if err := applyConfigFile(flag.CommandLine); err != nil {
+		log.Fatal(err)
+	}
+	inputsDir = expandHome(inputsDir) // config files get no shell expansion
+	setupLogging(os.Stderr, *verbose, *debug)
+	if *plain {
+		sym = plainSymbols
+		if !isFlagSet("progress") {
+			*showProgress = false
+		}
+	}
+	if *quiet && !isFlagSet("progress") {
+		*showProgress = false
+	}
+	if err := inputOverrides.resolve(*day, *part); err != nil {
+		log.Fatal(err)
+	}
+	if *benchFormat != "text" && *benchFormat != "go" {
+		log.Fatalf("unknown -bench-format %q (want text or go)", *benchFormat)
+	}
+	if repeat < 1 {
+		log.Fatalf("-repeat must be at least 1")
+	}
+	if repeat > 1 && *bench > 0 {
+		log.Fatalf("-repeat and -bench cannot be combined; -bench already repeats each solver")
+	}
+	if err := checkSummaryOnly(*summaryOnly, *output, *quiet, *verify); err != nil {
+		log.Fatal(err)
+	}
+
+	if *eval != "" {
+		if err := runEval(os.Stdout, *eval); err != nil {
+			log.Fatalf("eval: %v", err)
+		}
+		return
+	}
+
+	readStdin, err := stdinRequested(*stdin, flag.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if readStdin || isFlagSet("input-string") {
+		source, name := io.Reader(os.Stdin), "stdin"
+		if isFlagSet("input-string") {
+			if readStdin {
+				log.Fatalf("-input-string and -stdin both supply the input; pick one")
+			}
+			source, name = strings.NewReader(unescapeInput(*inputString)), "input-string"
+		}
+		if *day == 0 {
+			log.Fatalf("-%s needs a specific -day", name)
+		}
+		if err := checkInlineInput(name, *bench, *benchFormat, *verify); err != nil {
+			log.Fatal(err)
+		}
+		var rep Reporter = &quietReporter{w: os.Stdout, errs: os.Stderr}
+		if !*quiet {
+			if rep, err = newReporter(*output, os.Stdout, *summaryOnly); err != nil {
+				log.Fatal(err)
+			}
+		}
+		ok, err := runStdin(rep, source, *day, *part, *timeout)
+		switch {
+		case errors.Is(err, errNoSolvers):
+			fail(exitNoSolvers, "%s: %v", name, err)
+		case err != nil:
+			log.Fatalf("%s: %v", name, err)
+		case !ok:
+			os.Exit(exitSolverError)
+		}
+		return
+	}
+
 	toRun := filterSolvers(*day, *part)
 	if len(toRun) == 0 {
-		log.Fatalf("No solutions found for day %d part %d", *day, *part)
+		fail(exitNoSolvers, "No solutions found for day %d part %d", *day, *part)
+	}
+	if *day != 0 {
+		if err := checkUnlocked(*day, *force); err != nil {
+			log.Fatal(err)
+		}
 	}
 
-	printHeader()
-	totalStart := time.Now()
+	var setAnswers answers
+	if *setName != "" {
+		m, err := loadManifest(manifestPath())
+		if err != nil {
+			log.Fatal(err)
+		}
+		if toRun, setAnswers, err = m.selectSet(*setName, toRun); err != nil {
+			fail(exitNoSolvers, "%v", err)
+		}
+	}
 
-	for _, s := range toRun {
-		runSolver(s)
+	if *watch {
+		if *day == 0 {
+			log.Fatalf("-watch needs a specific -day")
+		}
+		log.Fatal(runWatch(os.Stdout, withoutFlag(os.Args[1:], "watch"), watchedFiles(*day, toRun)))
+	}
+
+	if *session != "" && !useExamples && *setName == "" {
+		fetchMissingInputs(os.Stderr, toRun, *session)
+	}
+
+	if isFlagSet("start") {
+		if *day != 1 {
+			log.Fatalf("-start only applies to day 1; add -day 1")
+		}
+		toRun = withStart(toRun, *start)
 	}
 
-	fmt.Printf("\n⏱️  Total time: %v\n", time.Since(totalStart))
+	// the timeout's context solvers outrank progress reporting: each only
+	// replaces a solve no other wrapper has changed, so it goes first
+	if *timeout > 0 {
+		toRun = withTimeout(toRun, *timeout, contextSolvers())
+	}
+
+	if *showProgress && *parallel <= 1 && *bench == 0 {
+		toRun = withProgress(toRun, os.Stderr, progressSolvers())
+	}
+
+	// measure reads process-wide allocation counters, which concurrent
+	// solvers would add to one another's
+	if measureMemory && *parallel > 1 {
+		log.Fatalf("-mem cannot be combined with -parallel")
+	}
+	if *cpuProfile != "" || *memProfile != "" {
+		if *parallel > 1 {
+			log.Fatalf("-cpuprofile and -memprofile cannot be combined with -parallel")
+		}
+		toRun = withProfiles(toRun, *cpuProfile, *memProfile)
+	}
+
+	if *bench > 0 && *benchFormat == "go" {
+		if !runBenchGo(os.Stdout, toRun, *warmup, *bench) {
+			os.Exit(exitSolverError)
+		}
+		return
+	}
+	if *bench > 0 {
+		printHeader(os.Stdout)
+		totalStart := time.Now()
+		ok := runBench(os.Stdout, toRun, *warmup, *bench)
+		fmt.Printf("\n%sTotal time: %v\n", sym.clock, sym.duration(time.Since(totalStart)))
+		if !ok {
+			os.Exit(exitSolverError)
+		}
+		return
+	}
+
+	if *noHistory && *compareBaseline > 0 {
+		log.Fatal("-compare-baseline needs the timing history, so it cannot be combined with -no-history")
+	}
+	recordTimings := !*noHistory
+	if name := otherInputFlag(useExamples, *setName, len(inputOverrides.overrides) > 0); name != "" {
+		if *compareBaseline > 0 {
+			log.Fatalf("-compare-baseline compares timings on the puzzle inputs, so it cannot be combined with %s", name)
+		}
+		recordTimings = false
+	}
+
+	out, err := openOutput(*outPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var reporter Reporter
+	if *verify {
+		known := setAnswers
+		if known == nil {
+			path, err := findAnswers(*answersPath)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if known, err = loadAnswers(path); err != nil {
+				log.Fatal(err)
+			}
+		}
+		reporter = &verifyReporter{w: out, answers: known, strict: *strict}
+	} else if *quiet {
+		reporter = &quietReporter{w: out, errs: os.Stderr}
+	} else {
+		reporter, err = newReporter(*output, out, *summaryOnly)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// measuring modes need the solvers to really run
+	if !*noCache && repeat == 1 && !measureMemory && maxTime == 0 && *cpuProfile == "" && *memProfile == "" {
+		if answerCache, err = openCache(cachePath); err != nil {
+			log.Printf("%sanswer cache: %v", sym.warn, err)
+		}
+	}
+
+	history := &historyReporter{Reporter: reporter}
+	ok, err := runParallel(history, toRun, *parallel)
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		log.Fatalf("writing report: %v", err)
+	}
+
+	if answerCache != nil {
+		if err := answerCache.save(); err != nil {
+			log.Printf("%s%v", sym.warn, err)
+		}
+	}
+
+	regressed := false
+	if recordTimings {
+		if regressed, err = recordHistory(os.Stderr, historyPath, history.entries, *compareBaseline); err != nil {
+			log.Printf("%stiming history: %v", sym.warn, err)
+		}
+	}
+
+	if code := exitCode(ok, reporter); code != 0 {
+		os.Exit(code)
+	}
+	if regressed {
+		os.Exit(exitRegression)
+	}
+}
+
+// exitCode picks the status for a finished run: -verify reports wrong answers
+// ahead of solvers that could not run, and every other mode only the latter
+func exitCode(ok bool, rep Reporter) int {
+	if v, verifying := rep.(*verifyReporter); verifying && v.wrong > 0 {
+		return exitWrongAnswer
+	}
+	if !ok {
+		return exitSolverError
+	}
+	return 0
+}
+
+// fail logs the message like log.Fatalf, but exits with code
+func fail(code int, format string, args ...any) {
+	log.Printf(format, args...)
+	os.Exit(code)
+}
+
+// runEval solves an inline day 1 program with both counters
+func runEval(w io.Writer, program string) error {
+	rotations, err := day1.ParseInline(program)
+	if err != nil {
+		return err
+	}
+
+	pipeline := day1.NewPipeline(rotations)
+	fmt.Fprintf(w, "Day 1 eval (%d rotations)\n", len(rotations))
+	fmt.Fprintf(w, "  ends at zero:   %d\n", pipeline.Reduce(day1.StartPosition, day1.EndPositionCounter{}))
+	fmt.Fprintf(w, "  zero crossings: %d\n", pipeline.CountCrossings(day1.StartPosition))
+	return nil
+}
+
+// stdinRequested reports whether input should come from stdin: -stdin, or a
+// lone "-" argument as in "-day 3 -part 1 -"
+func stdinRequested(stdin bool, args []string) (bool, error) {
+	switch {
+	case len(args) == 0:
+		return stdin, nil
+	case len(args) == 1 && args[0] == "-":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unexpected arguments %q (only \"-\" for stdin is accepted)", args)
+	}
+}
+
+// inputEscapes expands the escapes a shell leaves in -input-string as typed
+var inputEscapes = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\t`, "\t")
+
+// unescapeInput turns the -input-string text into the puzzle input, ending it
+// with a newline as an input file would
+func unescapeInput(s string) string {
+	s = inputEscapes.Replace(s)
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	return s
+}
+
+var errNoSolvers = errors.New("no solutions found")
+
+// checkInlineInput rejects the flags a -stdin or -input-string run would
+// ignore, as it solves each part just once and has no answers to check
+func checkInlineInput(name string, bench int, benchFormat string, verify bool) error {
+	var ignored string
+	switch {
+	case bench > 0:
+		ignored = "-bench"
+	case benchFormat != "text":
+		ignored = "-bench-format"
+	case verify:
+		ignored = "-verify"
+	case repeat > 1:
+		ignored = "-repeat"
+	case measureMemory:
+		ignored = "-mem"
+	case maxTime > 0:
+		ignored = "-max-time"
+	default:
+		return nil
+	}
+	return fmt.Errorf("-%s cannot be combined with %s; save the input to a file and pass it with -input", name, ignored)
+}
+
+// runStdin reads all of r once and runs the selected parts of day against it,
+// handing each result to rep, and reports whether every part succeeded. A
+// positive timeout bounds each part as -timeout does.
+func runStdin(rep Reporter, r io.Reader, day, part int, timeout time.Duration) (bool, error) {
+	var parts []registry.Solver
+	for _, s := range registry.All() {
+		if s.Day == day && (part == 0 || s.Part == part) && s.SolveReader != nil {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) == 0 {
+		return false, fmt.Errorf("%w for day %d part %d", errNoSolvers, day, part)
+	}
+
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return false, fmt.Errorf("reading input: %w", err)
+	}
+
+	rep.Start()
+	totalStart := time.Now()
+	ok := true
+	for _, s := range parts {
+		res := result{day: s.Day, part: s.Part}
+		start := time.Now()
+		if timeout > 0 {
+			res.value, res.err = solveWithin(timeout, func(context.Context, string) (int, error) {
+				return s.SolveReader(bytes.NewReader(input))
+			}, "")
+		} else {
+			res.value, res.err = s.SolveReader(bytes.NewReader(input))
+		}
+		res.elapsed = time.Since(start)
+		if res.err != nil {
+			ok = false
+		}
+		rep.Result(res)
+	}
+	return ok, rep.Finish(time.Since(totalStart))
 }
 
 func filterSolvers(day, part int) []solver {
@@ -86,27 +527,310 @@ func filterSolvers(day, part int) []solver {
 	return filtered
 }
 
-func runSolver(s solver) {
-	inputPath := filepath.Join("inputs", fmt.Sprintf("day%d_input.txt", s.day))
+// isFlagSet reports whether the named flag was passed on the command line
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
 
-	if _, err := os.Stat(inputPath); err != nil {
-		fmt.Printf("❌ Day %d Part %d: Input file not found\n", s.day, s.part)
-		return
+// withStart swaps day 1 solvers for versions whose dial begins at start
+func withStart(toRun []solver, start int) []solver {
+	startParts := []func(string, int) (int, error){day1.Part1At, day1.Part2At}
+
+	adjusted := slices.Clone(toRun)
+	for i, s := range adjusted {
+		if s.day != 1 || s.part > len(startParts) {
+			continue
+		}
+		solveAt := startParts[s.part-1]
+		adjusted[i] = s.wrap(func(path string) (int, error) {
+			return solveAt(path, start)
+		})
+		adjusted[i].variant = fmt.Sprintf("start:%d", start)
 	}
+	return adjusted
+}
 
-	start := time.Now()
-	result, err := s.solve(inputPath)
-	elapsed := time.Since(start)
+// inputsDir holds the dayN_input.txt files; set by -inputs-dir
+var inputsDir = "inputs"
 
-	if err != nil {
-		fmt.Printf("❌ Day %d Part %d: %v\n", s.day, s.part, err)
-	} else {
-		fmt.Printf("✅ Day %d Part %d: %d (%v)\n", s.day, s.part, result, elapsed)
+func inputPathFor(day int) string {
+	return filepath.Join(inputsDir, fmt.Sprintf("day%d_input.txt", day))
+}
+
+// defaultInputsDir is $AOC_INPUTS_DIR, or inputs/ under the current directory
+func defaultInputsDir() string {
+	if dir := os.Getenv("AOC_INPUTS_DIR"); dir != "" {
+		return dir
+	}
+	return "inputs"
+}
+
+// fetchMissingInputs downloads the input of every day in toRun whose file is
+// missing. Solvers pointed elsewhere with -input are left alone. Failures are
+// reported to w and leave the solver to report errInputNotFound as usual.
+func fetchMissingInputs(w io.Writer, toRun []solver, session string) {
+	fetched := make(map[int]bool)
+	for _, s := range toRun {
+		if _, overridden := inputOverrides.lookup(s.day, s.part); overridden || fetched[s.day] {
+			continue
+		}
+		fetched[s.day] = true
+
+		if fetch.CheckUnlocked(s.day, now()) != nil {
+			continue // a locked day has no input to download yet
+		}
+		if err := fetch.EnsureInput(inputPathFor(s.day), s.day, session); err != nil {
+			fmt.Fprintf(w, "%sDay %d: could not download input: %v\n", sym.warn, s.day, err)
+		}
+	}
+}
+
+var errInputNotFound = errors.New("input file not found")
+
+// errOverBudget marks a solver that answered but took longer than -max-time
+var errOverBudget = errors.New("over the time budget")
+
+// maxTime is the -max-time budget per part (0 for none)
+var maxTime time.Duration
+
+// repeat is how many times runSolver runs each solver (-repeat)
+var repeat = 1
+
+// result is the outcome of running a single solver. With -repeat, elapsed is
+// the median run and repeats holds every run's duration.
+type result struct {
+	day, part int
+	value     int
+	elapsed   time.Duration
+	err       error
+	repeats   benchStats
+	mem       *memUsage // the last run's allocations, with -mem
+	cached    bool      // answered from the cache without running the solver
+}
+
+func runSolver(s solver) result {
+	r := result{day: s.day, part: s.part}
+
+	path := inputPath(s.day, s.part)
+	if _, err := os.Stat(path); err != nil {
+		r.err = errInputNotFound
+		return r
+	}
+
+	var key string
+	if answerCache != nil {
+		// an unreadable input is left for the solver to report
+		if hash, err := fileHash(path); err == nil {
+			key = cacheKey(s, hash)
+			if value, ok := answerCache.lookup(key); ok {
+				slog.Info("answer cached", "day", s.day, "part", s.part, "input", path)
+				r.value, r.cached = value, true
+				return r
+			}
+		}
+	}
+
+	slog.Info("solving", "day", s.day, "part", s.part, "input", path)
+	var samples []time.Duration
+	for i := range max(repeat, 1) {
+		var value int
+		var err error
+		solve := func() {
+			start := time.Now()
+			value, err = s.solve(path)
+			r.elapsed = time.Since(start)
+		}
+		if measureMemory {
+			usage := measure(solve)
+			r.mem = &usage
+		} else {
+			solve()
+		}
+		if err != nil {
+			r.value, r.err = value, err
+			return r
+		}
+		if i > 0 && value != r.value {
+			r.err = fmt.Errorf("answer changed between runs: %d, then %d", r.value, value)
+			return r
+		}
+		r.value = value
+		samples = append(samples, r.elapsed)
+	}
+
+	if len(samples) > 1 {
+		r.repeats = newBenchStats(samples)
+		r.elapsed = r.repeats.Percentile(50)
+	}
+	if maxTime > 0 && r.elapsed > maxTime {
+		r.err = fmt.Errorf("%w: answered %d in %s, budget %s", errOverBudget, r.value, sym.duration(r.elapsed), sym.duration(maxTime))
+	}
+	if r.err == nil && key != "" {
+		answerCache.store(key, r.value)
+	}
+	return r
+}
+
+// runAll runs every solver, handing each result to rep as it finishes, and
+// reports whether every solver succeeded
+func runAll(rep Reporter, toRun []solver) (bool, error) {
+	return runParallel(rep, toRun, 1)
+}
+
+// runParallel is runAll with up to workers solvers running at once. Results
+// still reach rep in toRun order: a finished solver waits for the ones listed
+// before it.
+func runParallel(rep Reporter, toRun []solver, workers int) (bool, error) {
+	rep.Start()
+	totalStart := time.Now()
+
+	// one buffered slot per solver, so workers never block on a slow reporter
+	slots := make([]chan result, len(toRun))
+	for i := range slots {
+		slots[i] = make(chan result, 1)
+	}
+
+	next := make(chan int)
+	go func() {
+		for i := range toRun {
+			next <- i
+		}
+		close(next)
+	}()
+	for range max(workers, 1) {
+		go func() {
+			for i := range next {
+				slots[i] <- runSolver(toRun[i])
+			}
+		}()
+	}
+
+	ok := true
+	for _, slot := range slots {
+		r := <-slot
+		if r.err != nil {
+			ok = false
+		}
+		rep.Result(r)
+	}
+
+	return ok, rep.Finish(time.Since(totalStart))
+}
+
+// benchStats summarizes the durations collected across repeated runs of a solver
+type benchStats struct {
+	samples []time.Duration // sorted ascending
+}
+
+func newBenchStats(samples []time.Duration) benchStats {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	return benchStats{samples: sorted}
+}
+
+func (b benchStats) Min() time.Duration {
+	return b.Percentile(0)
+}
+
+func (b benchStats) Max() time.Duration {
+	return b.Percentile(100)
+}
+
+// Mean returns the average sample
+func (b benchStats) Mean() time.Duration {
+	if len(b.samples) == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, d := range b.samples {
+		total += d
+	}
+	return total / time.Duration(len(b.samples))
+}
+
+// StdDev returns the sample standard deviation, or 0 for fewer than two samples
+func (b benchStats) StdDev() time.Duration {
+	if len(b.samples) < 2 {
+		return 0
+	}
+
+	mean := float64(b.Mean())
+	sumSquares := 0.0
+	for _, d := range b.samples {
+		diff := float64(d) - mean
+		sumSquares += diff * diff
+	}
+	return time.Duration(math.Sqrt(sumSquares / float64(len(b.samples)-1)))
+}
+
+// Percentile returns the nearest-rank p-th percentile (0-100) of the samples
+func (b benchStats) Percentile(p float64) time.Duration {
+	if len(b.samples) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(b.samples))))
+	rank = min(max(rank, 1), len(b.samples))
+	return b.samples[rank-1]
+}
+
+// runBench times iterations runs of each solver after warmup untimed runs,
+// which absorb first-run costs such as cold caches and page faults. It
+// reports whether every solver ran.
+func runBench(w io.Writer, toRun []solver, warmup, iterations int) bool {
+	fmt.Fprintf(w, "%-4s %-4s %12s %12s %12s %12s %12s %12s %12s\n",
+		"Day", "Part", "min", "median", "mean", "p90", "p99", "max", "stddev")
+
+	ok := true
+	for _, s := range toRun {
+		path := inputPath(s.day, s.part)
+		if _, err := os.Stat(path); err != nil {
+			ok = false
+			fmt.Fprintf(w, "%sDay %d Part %d: %v\n", sym.fail, s.day, s.part, errInputNotFound)
+			continue
+		}
+
+		samples, solveErr := benchSamples(s, path, warmup, iterations)
+		if solveErr != nil {
+			ok = false
+			fmt.Fprintf(w, "%sDay %d Part %d: %v\n", sym.fail, s.day, s.part, solveErr)
+			continue
+		}
+
+		stats := newBenchStats(samples)
+		fmt.Fprintf(w, "%-4d %-4d %12v %12v %12v %12v %12v %12v %12v\n", s.day, s.part,
+			sym.duration(stats.Min()), sym.duration(stats.Percentile(50)), sym.duration(stats.Mean()),
+			sym.duration(stats.Percentile(90)), sym.duration(stats.Percentile(99)),
+			sym.duration(stats.Max()), sym.duration(stats.StdDev()))
+	}
+	return ok
+}
+
+// benchSamples times iterations runs of s on path after warmup untimed ones,
+// stopping at the first error
+func benchSamples(s solver, path string, warmup, iterations int) ([]time.Duration, error) {
+	samples := make([]time.Duration, 0, iterations)
+	for i := range warmup + iterations {
+		start := time.Now()
+		if _, err := s.solve(path); err != nil {
+			return nil, err
+		}
+		if i >= warmup {
+			samples = append(samples, time.Since(start))
+		}
 	}
+	return samples, nil
 }
 
-func printHeader() {
-	fmt.Println("🎄 Advent of Code 2025 Runner")
-	fmt.Println(strings.Repeat("=", 50))
-	fmt.Println()
+func printHeader(w io.Writer) {
+	fmt.Fprintln(w, sym.title+"Advent of Code 2025 Runner")
+	fmt.Fprintln(w, strings.Repeat("=", 50))
+	fmt.Fprintln(w)
 }
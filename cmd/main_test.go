@@ -0,0 +1,421 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"adv2025/aoc/registry"
+)
+
+// withInputs changes into a temporary directory holding empty input files for the given days
+func withInputs(t *testing.T, days ...int) {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "inputs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, day := range days {
+		path := filepath.Join(dir, inputPathFor(day))
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+}
+
+func fixed(value int) func(string) (int, error) {
+	return func(string) (int, error) { return value, nil }
+}
+
+func TestBenchStatsPercentile(t *testing.T) {
+	var samples []time.Duration
+	for i := 10; i >= 1; i-- { // deliberately unsorted
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	stats := newBenchStats(samples)
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 1 * time.Millisecond},
+		{50, 5 * time.Millisecond},
+		{90, 9 * time.Millisecond},
+		{99, 10 * time.Millisecond},
+		{100, 10 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := stats.Percentile(tt.p); got != tt.want {
+			t.Errorf("Percentile(%v): expected %v, got %v", tt.p, tt.want, got)
+		}
+	}
+}
+
+func TestBenchStatsEmpty(t *testing.T) {
+	if got := newBenchStats(nil).Percentile(50); got != 0 {
+		t.Errorf("expected 0 for empty samples, got %v", got)
+	}
+}
+
+func TestBenchStatsMeanStdDev(t *testing.T) {
+	var samples []time.Duration
+	for _, ms := range []int{2, 4, 4, 4, 5, 5, 7, 9} {
+		samples = append(samples, time.Duration(ms)*time.Millisecond)
+	}
+	stats := newBenchStats(samples)
+
+	if got := stats.Mean(); got != 5*time.Millisecond {
+		t.Errorf("Mean: expected 5ms, got %v", got)
+	}
+	// sum of squared deviations is 32ms², over n-1 = 7
+	want := time.Duration(math.Sqrt(32.0/7) * float64(time.Millisecond))
+	if got := stats.StdDev(); got != want {
+		t.Errorf("StdDev: expected %v, got %v", want, got)
+	}
+
+	single := newBenchStats(samples[:1])
+	if single.Mean() != 2*time.Millisecond || single.StdDev() != 0 {
+		t.Errorf("single sample: expected mean 2ms and stddev 0, got %v and %v", single.Mean(), single.StdDev())
+	}
+}
+
+func TestRunBenchWarmup(t *testing.T) {
+	withInputs(t, 1)
+	calls := 0
+	counting := func(string) (int, error) {
+		calls++
+		return 0, nil
+	}
+
+	var out bytes.Buffer
+	runBench(&out, []solver{{day: 1, part: 1, solve: counting}}, 2, 5)
+	if calls != 7 {
+		t.Errorf("expected 2 warmup + 5 timed runs, got %d calls", calls)
+	}
+	if got := out.String(); !strings.Contains(got, "stddev") || !strings.Contains(got, "\n1    1 ") {
+		t.Errorf("expected a header and a row for day 1 part 1:\n%s", got)
+	}
+}
+
+func TestRunAllSummaryOnly(t *testing.T) {
+	withInputs(t, 1)
+	toRun := []solver{
+		{day: 1, part: 1, solve: fixed(42)},
+		{day: 1, part: 2, solve: func(string) (int, error) { return 0, errors.New("boom") }},
+		{day: 2, part: 1, solve: fixed(7)}, // no input file
+	}
+
+	var out bytes.Buffer
+	if ok, err := runAll(&textReporter{w: &out, summaryOnly: true}, toRun); ok || err != nil {
+		t.Errorf("expected a failed run without a write error, got ok=%v err=%v", ok, err)
+	}
+	got := out.String()
+
+	for _, unwanted := range []string{"✅", "❌", "Runner"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("summary-only output should not contain %q:\n%s", unwanted, got)
+		}
+	}
+	for _, want := range []string{"1.1 = 42\n", "1.2 = error: boom\n", "2.1 = error: input file not found\n", "Total time"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summary-only output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunAllVerbose(t *testing.T) {
+	withInputs(t, 1)
+
+	var out bytes.Buffer
+	if ok, _ := runAll(&textReporter{w: &out}, []solver{{day: 1, part: 1, solve: fixed(42)}}); !ok {
+		t.Error("expected every solver to succeed")
+	}
+
+	if got := out.String(); !strings.Contains(got, "✅ Day 1 Part 1: 42") || strings.Contains(got, "1.1 = 42") {
+		t.Errorf("expected a per-solver line and no summary table:\n%s", got)
+	}
+}
+
+func TestRunSolverRepeat(t *testing.T) {
+	withInputs(t, 1)
+	repeat = 5
+	t.Cleanup(func() { repeat = 1 })
+
+	calls := 0
+	r := runSolver(solver{day: 1, part: 1, solve: func(string) (int, error) { calls++; return 42, nil }})
+	if r.err != nil || r.value != 42 || calls != 5 || len(r.repeats.samples) != 5 {
+		t.Fatalf("expected 5 runs answering 42, got %d runs: %+v", calls, r)
+	}
+	if r.elapsed != r.repeats.Percentile(50) {
+		t.Errorf("expected the median as the elapsed time, got %v", r.elapsed)
+	}
+
+	calls = 0
+	r = runSolver(solver{day: 1, part: 1, solve: func(string) (int, error) { calls++; return calls, nil }})
+	if r.err == nil || !strings.Contains(r.err.Error(), "answer changed") {
+		t.Errorf("expected a differing answer to fail the part, got %v", r.err)
+	}
+
+	var out bytes.Buffer
+	runAll(&textReporter{w: &out, summaryOnly: true}, []solver{{day: 1, part: 1, solve: fixed(42)}})
+	if got := out.String(); !strings.Contains(got, "1.1 = 42\n") || !strings.Contains(got, "1.1: p50 ") || !strings.Contains(got, "over 5 runs") {
+		t.Errorf("expected the answer and its percentiles in the summary:\n%s", got)
+	}
+}
+
+func TestRunSolverMaxTime(t *testing.T) {
+	withInputs(t, 1)
+	maxTime = time.Millisecond
+	t.Cleanup(func() { maxTime = 0 })
+
+	slow := func(string) (int, error) { time.Sleep(5 * time.Millisecond); return 42, nil }
+	r := runSolver(solver{day: 1, part: 1, solve: slow})
+	if !errors.Is(r.err, errOverBudget) || !strings.Contains(r.err.Error(), "answered 42") {
+		t.Errorf("expected an over-budget error keeping the answer, got %v", r.err)
+	}
+	if r := runSolver(solver{day: 1, part: 2, solve: fixed(7)}); r.err != nil {
+		t.Errorf("a fast solver should stay within budget, got %v", r.err)
+	}
+}
+
+func TestRunEval(t *testing.T) {
+	var out bytes.Buffer
+	if err := runEval(&out, "L68,L30,R48,L5,R60,L55,L1,L99,R14,L82"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{"10 rotations", "ends at zero:   3\n", "zero crossings: 6\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("eval output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunEvalInvalid(t *testing.T) {
+	if err := runEval(&bytes.Buffer{}, "L68,X5"); err == nil {
+		t.Error("expected an error for an invalid rotation")
+	}
+}
+
+func TestSolversComeFromRegistry(t *testing.T) {
+	if len(solvers) != 24 {
+		t.Fatalf("expected 12 days x 2 parts registered, got %d", len(solvers))
+	}
+	for i, s := range solvers {
+		if day, part := i/2+1, i%2+1; s.day != day || s.part != part {
+			t.Errorf("solver %d: expected day %d part %d, got day %d part %d", i, day, part, s.day, s.part)
+		}
+	}
+}
+
+func TestRunParallelKeepsOrder(t *testing.T) {
+	withInputs(t, 1, 2, 3)
+
+	// later solvers finish first, so results arrive out of order
+	sleepy := func(value int, d time.Duration) func(string) (int, error) {
+		return func(string) (int, error) {
+			time.Sleep(d)
+			return value, nil
+		}
+	}
+	toRun := []solver{
+		{day: 1, part: 1, solve: sleepy(11, 30*time.Millisecond)},
+		{day: 2, part: 1, solve: sleepy(21, 15*time.Millisecond)},
+		{day: 3, part: 1, solve: sleepy(31, 0)},
+		{day: 4, part: 1, solve: fixed(41)}, // no input file
+	}
+
+	var out bytes.Buffer
+	if ok, err := runParallel(&textReporter{w: &out, summaryOnly: true}, toRun, 4); ok || err != nil {
+		t.Errorf("expected a failed run without a write error, got ok=%v err=%v", ok, err)
+	}
+	want := "1.1 = 11\n2.1 = 21\n3.1 = 31\n4.1 = error: input file not found\n"
+	if got := out.String(); !strings.HasPrefix(got, want) {
+		t.Errorf("expected results in day order:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestWithStart(t *testing.T) {
+	withInputs(t)
+	if err := os.WriteFile(inputPathFor(1), []byte("R10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	toRun := []solver{{day: 1, part: 1, solve: fixed(0)}, {day: 1, part: 2, solve: fixed(0)}, {day: 2, part: 1, solve: fixed(7)}}
+
+	adjusted := withStart(toRun, 90)
+	for i, want := range []int{1, 1, 7} { // R10 from 90 lands on 0
+		got, err := adjusted[i].solve(inputPathFor(adjusted[i].day))
+		if err != nil || got != want {
+			t.Errorf("solver %d.%d = %d, %v; want %d", adjusted[i].day, adjusted[i].part, got, err, want)
+		}
+	}
+	if got, _ := toRun[0].solve(""); got != 0 {
+		t.Errorf("withStart should not modify its input, got %d", got)
+	}
+}
+
+func TestRunStdin(t *testing.T) {
+	var out bytes.Buffer
+	input := strings.NewReader("L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\n")
+	if ok, err := runStdin(&textReporter{w: &out}, input, 1, 0, 0); !ok || err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{"✅ Day 1 Part 1: 3 (", "✅ Day 1 Part 2: 6 ("} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunStdinSinglePart(t *testing.T) {
+	var out bytes.Buffer
+	if ok, err := runStdin(&textReporter{w: &out}, strings.NewReader("11-22,95-115\n"), 2, 1, 0); !ok || err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "✅ Day 2 Part 1: 132 (") || strings.Contains(got, "Part 2") {
+		t.Errorf("expected only day 2 part 1 = 132:\n%s", got)
+	}
+
+	if _, err := runStdin(&textReporter{w: &out}, strings.NewReader(""), 99, 0, 0); !errors.Is(err, errNoSolvers) {
+		t.Errorf("expected errNoSolvers for an unknown day, got %v", err)
+	}
+	if ok, err := runStdin(&textReporter{w: &out}, strings.NewReader(""), 2, 1, 0); ok || err != nil {
+		t.Errorf("expected empty day 2 input to fail the solver, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestRunStdinTimeout(t *testing.T) {
+	release := make(chan struct{})
+	registry.Register(96, 1, func(string) (int, error) { return 0, nil })
+	registry.RegisterReader(96, 1, func(io.Reader) (int, error) {
+		<-release
+		return 1, nil
+	})
+	t.Cleanup(func() {
+		close(release)
+		registry.Unregister(96, 1)
+	})
+
+	var out bytes.Buffer
+	if ok, err := runStdin(&textReporter{w: &out}, strings.NewReader(""), 96, 1, time.Millisecond); ok || err != nil {
+		t.Fatalf("expected the part to time out, got ok=%v err=%v", ok, err)
+	}
+	if !strings.Contains(out.String(), "timed out after 1ms") {
+		t.Errorf("expected a timeout reported:\n%s", out.String())
+	}
+}
+
+func TestCheckInlineInput(t *testing.T) {
+	if err := checkInlineInput("stdin", 0, "text", false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, tt := range []struct {
+		bench       int
+		benchFormat string
+		verify      bool
+		want        string
+	}{
+		{3, "text", false, "-bench"},
+		{0, "go", false, "-bench-format"},
+		{0, "text", true, "-verify"},
+	} {
+		err := checkInlineInput("input-string", tt.bench, tt.benchFormat, tt.verify)
+		if err == nil || !strings.Contains(err.Error(), "-input-string cannot be combined with "+tt.want+";") {
+			t.Errorf("expected %s rejected, got %v", tt.want, err)
+		}
+	}
+
+	repeat, measureMemory = 3, true
+	t.Cleanup(func() { repeat, measureMemory = 1, false })
+	if err := checkInlineInput("stdin", 0, "text", false); err == nil || !strings.Contains(err.Error(), "-repeat") {
+		t.Errorf("expected -repeat rejected, got %v", err)
+	}
+}
+
+func TestUnescapeInput(t *testing.T) {
+	tests := map[string]string{
+		`L68\nR48`:   "L68\nR48\n",
+		"L68\nR48\n": "L68\nR48\n",
+		`a\tb\\n`:    "a\tb\\n\n",
+	}
+	for in, want := range tests {
+		if got := unescapeInput(in); got != want {
+			t.Errorf("unescapeInput(%q): expected %q, got %q", in, want, got)
+		}
+	}
+
+	var out bytes.Buffer
+	if ok, err := runStdin(&textReporter{w: &out}, strings.NewReader(unescapeInput(`L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82`)), 1, 1, 0); !ok || err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Day 1 Part 1: 3 (") {
+		t.Errorf("expected the example's answer:\n%s", out.String())
+	}
+}
+
+func TestStdinRequested(t *testing.T) {
+	tests := []struct {
+		stdin bool
+		args  []string
+		want  bool
+	}{
+		{false, nil, false},
+		{true, nil, true},
+		{false, []string{"-"}, true},
+	}
+	for _, tt := range tests {
+		if got, err := stdinRequested(tt.stdin, tt.args); err != nil || got != tt.want {
+			t.Errorf("stdinRequested(%v, %q) = %v, %v; want %v", tt.stdin, tt.args, got, err, tt.want)
+		}
+	}
+
+	for _, args := range [][]string{{"input.txt"}, {"-", "-"}} {
+		if _, err := stdinRequested(false, args); err == nil {
+			t.Errorf("stdinRequested(%q): expected an error", args)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		ok   bool
+		rep  Reporter
+		want int
+	}{
+		{"all solved", true, &textReporter{}, 0},
+		{"solver failed", false, &textReporter{}, exitSolverError},
+		{"verified", true, &verifyReporter{passed: 2}, 0},
+		{"verify skipped an unrecorded answer", true, &verifyReporter{passed: 1, skipped: 1}, 0},
+		{"verify missing input", false, &verifyReporter{passed: 1, failed: 1, errored: 1}, exitSolverError},
+		{"verify solver error", false, &verifyReporter{failed: 1, errored: 1}, exitSolverError},
+		{"wrong answer wins", false, &verifyReporter{failed: 2, errored: 1, wrong: 1}, exitWrongAnswer},
+	}
+	for _, tt := range tests {
+		if got := exitCode(tt.ok, tt.rep); got != tt.want {
+			t.Errorf("%s: expected exit code %d, got %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestRunBenchReportsFailures(t *testing.T) {
+	withInputs(t, 1)
+	var out bytes.Buffer
+	if !runBench(&out, []solver{{day: 1, part: 1, solve: fixed(1)}}, 0, 1) {
+		t.Error("expected a clean bench run to succeed")
+	}
+	if runBench(&out, []solver{{day: 2, part: 1, solve: fixed(1)}}, 0, 1) {
+		t.Error("expected a missing input to fail the bench run")
+	}
+}
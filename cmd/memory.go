@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+// measureMemory makes runSolver record each solver's allocations (-mem)
+var measureMemory bool
+
+// heapSampleInterval is how often the peak heap is sampled while a solver runs
+var heapSampleInterval = time.Millisecond
+
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// memUsage is what one solve allocated. peakHeap is sampled, so a spike
+// shorter than heapSampleInterval can be missed.
+type memUsage struct {
+	allocs, allocBytes uint64
+	peakHeap           uint64
+}
+
+// measure runs solve and reports its allocations and the peak live heap
+// while it ran. A GC first clears out garbage left by earlier solvers.
+func measure(solve func()) memUsage {
+	// the sampler is set up before the first reading so its own
+	// allocations are not charged to the solver
+	done := make(chan struct{})
+	peak := make(chan uint64)
+	sample := []metrics.Sample{{Name: heapObjectsMetric}}
+	ticker := time.NewTicker(heapSampleInterval)
+	defer ticker.Stop()
+
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	go func() {
+		highest := uint64(0)
+		for {
+			metrics.Read(sample)
+			highest = max(highest, sample[0].Value.Uint64())
+			select {
+			case <-done:
+				peak <- highest
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	solve()
+	runtime.ReadMemStats(&after)
+	close(done)
+
+	return memUsage{
+		allocs:     after.Mallocs - before.Mallocs,
+		allocBytes: after.TotalAlloc - before.TotalAlloc,
+		peakHeap:   max(<-peak, after.HeapAlloc),
+	}
+}
+
+func (m memUsage) String() string {
+	return fmt.Sprintf("%s in %d allocs, peak heap %s", formatBytes(m.allocBytes), m.allocs, formatBytes(m.peakHeap))
+}
+
+// formatBytes renders n in the largest binary unit that keeps it at least 1
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, suffix := float64(n), "KMGT"
+	i := -1
+	for value >= unit && i < len(suffix)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, suffix[i])
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+var sink []byte
+
+func TestMeasure(t *testing.T) {
+	usage := measure(func() {
+		for range 10 {
+			sink = make([]byte, 1<<20)
+		}
+	})
+	if usage.allocs < 10 || usage.allocBytes < 10<<20 {
+		t.Errorf("expected at least 10 allocations of 1 MiB, got %d allocs of %d bytes", usage.allocs, usage.allocBytes)
+	}
+	if usage.peakHeap < 1<<20 {
+		t.Errorf("expected a peak heap of at least 1 MiB, got %d", usage.peakHeap)
+	}
+}
+
+func TestRunSolverMem(t *testing.T) {
+	withInputs(t, 1)
+	measureMemory = true
+	t.Cleanup(func() { measureMemory = false })
+
+	r := runSolver(solver{day: 1, part: 1, solve: func(string) (int, error) { sink = make([]byte, 4096); return 1, nil }})
+	if r.mem == nil || r.mem.allocBytes < 4096 {
+		t.Fatalf("expected the solver's allocation to be recorded, got %+v", r.mem)
+	}
+	if got := timing(r); !strings.Contains(got, "allocs, peak heap") {
+		t.Errorf("expected allocations in the timing, got %q", got)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[uint64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KiB", 3 << 20: "3.0 MiB"} {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d): expected %q, got %q", n, want, got)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+)
+
+// profilePath derives the profile file for one solver from a -cpuprofile or
+// -memprofile value: cpu.pprof becomes cpu.day2.part1.pprof
+func profilePath(base string, day, part int) string {
+	ext := filepath.Ext(base)
+	return fmt.Sprintf("%s.day%d.part%d%s", strings.TrimSuffix(base, ext), day, part, ext)
+}
+
+// withProfiles wraps every solver in toRun with pprof capture, writing one CPU
+// and/or heap profile per day and part. Empty paths disable that profile.
+// CPU profiling is process-wide, so solvers must not run concurrently.
+func withProfiles(toRun []solver, cpuProfile, memProfile string) []solver {
+	profiled := make([]solver, len(toRun))
+	for i, s := range toRun {
+		solve := s.solve
+		if cpuProfile != "" {
+			solve = withCPUProfile(solve, profilePath(cpuProfile, s.day, s.part))
+		}
+		if memProfile != "" {
+			solve = withMemProfile(solve, profilePath(memProfile, s.day, s.part))
+		}
+		profiled[i] = s.wrap(solve)
+	}
+	return profiled
+}
+
+func withCPUProfile(solve func(string) (int, error), path string) func(string) (int, error) {
+	return func(input string) (int, error) {
+		f, err := os.Create(path)
+		if err != nil {
+			return 0, fmt.Errorf("creating CPU profile: %w", err)
+		}
+		defer f.Close()
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return 0, fmt.Errorf("starting CPU profile: %w", err)
+		}
+		value, solveErr := solve(input)
+		pprof.StopCPUProfile()
+
+		if err := f.Close(); err != nil && solveErr == nil {
+			return value, fmt.Errorf("writing CPU profile: %w", err)
+		}
+		return value, solveErr
+	}
+}
+
+// withMemProfile writes a heap profile once solve returns. In-use figures
+// reflect what the solver still holds; allocation totals are cumulative over
+// the whole process, so pair it with -day/-part to isolate one solver.
+func withMemProfile(solve func(string) (int, error), path string) func(string) (int, error) {
+	return func(input string) (int, error) {
+		value, solveErr := solve(input)
+
+		f, err := os.Create(path)
+		if err != nil {
+			return value, fmt.Errorf("creating memory profile: %w", err)
+		}
+		defer f.Close()
+
+		runtime.GC() // bring the in-use statistics up to date
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			return value, fmt.Errorf("writing memory profile: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return value, fmt.Errorf("writing memory profile: %w", err)
+		}
+		return value, solveErr
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfilePath(t *testing.T) {
+	tests := []struct {
+		base string
+		want string
+	}{
+		{"cpu.pprof", "cpu.day2.part1.pprof"},
+		{"prof/mem", "prof/mem.day2.part1"},
+		{"out.d/cpu.prof", "out.d/cpu.day2.part1.prof"},
+	}
+	for _, tt := range tests {
+		if got := profilePath(tt.base, 2, 1); got != tt.want {
+			t.Errorf("profilePath(%q): expected %q, got %q", tt.base, tt.want, got)
+		}
+	}
+}
+
+func TestWithProfiles(t *testing.T) {
+	dir := t.TempDir()
+	cpu, mem := filepath.Join(dir, "cpu.pprof"), filepath.Join(dir, "mem.pprof")
+
+	profiled := withProfiles([]solver{{day: 3, part: 2, solve: fixed(32)}}, cpu, mem)
+	if got, err := profiled[0].solve(""); got != 32 || err != nil {
+		t.Fatalf("expected the solver's result, got %d, %v", got, err)
+	}
+
+	for _, path := range []string{"cpu.day3.part2.pprof", "mem.day3.part2.pprof"} {
+		info, err := os.Stat(filepath.Join(dir, path))
+		if err != nil || info.Size() == 0 {
+			t.Errorf("expected a non-empty %s, got %v", path, err)
+		}
+	}
+}
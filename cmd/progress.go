@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"adv2025/aoc/registry"
+)
+
+// progressDelay is how long a solver runs before its progress line appears,
+// so quick solvers never flicker one onto the screen
+var progressDelay = 2 * time.Second
+
+const progressTick = 100 * time.Millisecond
+
+// progressSolvers returns the registered parts that report their progress
+func progressSolvers() map[[2]int]func(string, registry.Progress) (int, error) {
+	aware := make(map[[2]int]func(string, registry.Progress) (int, error))
+	for _, s := range registry.All() {
+		if s.SolveProgress != nil {
+			aware[[2]int{s.Day, s.Part}] = s.SolveProgress
+		}
+	}
+	return aware
+}
+
+// withProgress shows a live line on w for every solver in toRun that is still
+// running after progressDelay: percent complete for parts found in aware that
+// no other wrapper has changed yet, a spinner and the elapsed time for the
+// rest. The line is erased once the solver returns. Solvers must not run
+// concurrently, as they share one line.
+func withProgress(toRun []solver, w io.Writer, aware map[[2]int]func(string, registry.Progress) (int, error)) []solver {
+	tracked := make([]solver, len(toRun))
+	for i, s := range toRun {
+		solveProgress, reports := aware[[2]int{s.day, s.part}]
+		reports = reports && !s.wrapped
+		plain := s.solve
+		tracked[i] = s.wrap(func(path string) (int, error) {
+			line := startProgressLine(w, s.day, s.part)
+			defer line.stop()
+
+			if reports {
+				return solveProgress(path, line.update)
+			}
+			return plain(path)
+		})
+	}
+	return tracked
+}
+
+// progressLine renders one solver's progress until stopped
+type progressLine struct {
+	w         io.Writer
+	day, part int
+	start     time.Time
+
+	mu          sync.Mutex
+	done, total int
+
+	quit     chan struct{}
+	finished chan struct{}
+}
+
+func startProgressLine(w io.Writer, day, part int) *progressLine {
+	p := &progressLine{
+		w: w, day: day, part: part, start: time.Now(),
+		quit: make(chan struct{}), finished: make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// update is the registry.Progress handed to the solver
+func (p *progressLine) update(done, total int) {
+	p.mu.Lock()
+	p.done, p.total = done, total
+	p.mu.Unlock()
+}
+
+func (p *progressLine) run() {
+	defer close(p.finished)
+
+	select {
+	case <-p.quit:
+		return
+	case <-time.After(progressDelay):
+	}
+
+	ticker := time.NewTicker(progressTick)
+	defer ticker.Stop()
+	for frame := 0; ; frame++ {
+		p.render(sym.spinner[frame%len(sym.spinner)])
+		select {
+		case <-p.quit:
+			fmt.Fprint(p.w, "\r\033[K") // erase the line before the result prints
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *progressLine) render(spinner rune) {
+	p.mu.Lock()
+	done, total := p.done, p.total
+	p.mu.Unlock()
+
+	elapsed := time.Since(p.start).Truncate(time.Second)
+	if total > 0 {
+		fmt.Fprintf(p.w, "\r\033[K%c Day %d Part %d: %3d%% (%d/%d) %v",
+			spinner, p.day, p.part, 100*done/total, done, total, elapsed)
+		return
+	}
+	fmt.Fprintf(p.w, "\r\033[K%c Day %d Part %d: running %v", spinner, p.day, p.part, elapsed)
+}
+
+// stop ends the line and waits until it has been erased
+func (p *progressLine) stop() {
+	close(p.quit)
+	<-p.finished
+}
+
+// isTerminal reports whether f is an interactive terminal rather than a file
+// or pipe, where a constantly rewritten line would only add noise
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
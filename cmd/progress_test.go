@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"adv2025/aoc/registry"
+)
+
+func TestWithProgress(t *testing.T) {
+	old := progressDelay
+	progressDelay = 0
+	t.Cleanup(func() { progressDelay = old })
+
+	aware := map[[2]int]func(string, registry.Progress) (int, error){
+		{2, 2}: func(_ string, progress registry.Progress) (int, error) {
+			progress(1, 2)
+			time.Sleep(3 * progressTick)
+			return 22, nil
+		},
+	}
+	slow := func(string) (int, error) {
+		time.Sleep(3 * progressTick)
+		return 11, nil
+	}
+
+	var out bytes.Buffer
+	tracked := withProgress([]solver{{day: 1, part: 1, solve: slow}, {day: 2, part: 2, solve: fixed(0)}}, &out, aware)
+
+	if got, err := tracked[0].solve(""); got != 11 || err != nil {
+		t.Errorf("expected the plain solver's result, got %d, %v", got, err)
+	}
+	if got := out.String(); !strings.Contains(got, "Day 1 Part 1: running") || !strings.HasSuffix(got, "\r\033[K") {
+		t.Errorf("expected a spinner line that is erased at the end, got %q", got)
+	}
+
+	out.Reset()
+	if got, err := tracked[1].solve(""); got != 22 || err != nil {
+		t.Errorf("expected the progress solver's result, got %d, %v", got, err)
+	}
+	if got := out.String(); !strings.Contains(got, "Day 2 Part 2:  50% (1/2)") {
+		t.Errorf("expected a percentage line, got %q", got)
+	}
+}
+
+func TestWithProgressKeepsProfiles(t *testing.T) {
+	old := progressDelay
+	progressDelay = 0
+	t.Cleanup(func() { progressDelay = old })
+
+	aware := map[[2]int]func(string, registry.Progress) (int, error){
+		{2, 2}: func(string, registry.Progress) (int, error) { return 22, nil },
+	}
+	dir := t.TempDir()
+	cpu := filepath.Join(dir, "cpu.pprof")
+
+	// either order keeps the profile: -cpuprofile wraps -progress in main, and
+	// a profiled solve is never swapped for the progress variant
+	var out bytes.Buffer
+	for _, toRun := range [][]solver{
+		withProfiles(withProgress([]solver{{day: 2, part: 2, solve: fixed(22)}}, &out, aware), cpu, ""),
+		withProgress(withProfiles([]solver{{day: 2, part: 2, solve: fixed(22)}}, cpu, ""), &out, aware),
+	} {
+		os.Remove(filepath.Join(dir, "cpu.day2.part2.pprof"))
+		if got, err := toRun[0].solve(""); got != 22 || err != nil {
+			t.Errorf("expected day 2 part 2's result, got %d, %v", got, err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "cpu.day2.part2.pprof")); err != nil {
+			t.Errorf("expected a CPU profile alongside -progress: %v", err)
+		}
+	}
+}
+
+func TestWithProgressQuietForFastSolvers(t *testing.T) {
+	var out bytes.Buffer
+	tracked := withProgress([]solver{{day: 1, part: 1, solve: fixed(1)}}, &out, nil)
+	if _, err := tracked[0].solve(""); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("a solver finishing before progressDelay should print nothing, got %q", out.String())
+	}
+}
+
+func TestProgressSolversIncludesDay2(t *testing.T) {
+	if _, ok := progressSolvers()[[2]int{2, 2}]; !ok {
+		t.Error("expected day 2 part 2 to report progress")
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"adv2025/aoc/fetch"
+)
+
+// puzzlesDir holds the cached statements, one dayN.md per day
+var puzzlesDir = "puzzles"
+
+// runPuzzle prints day's statement as Markdown, downloading it on first use.
+// Part 2 only appears with a session whose part 1 is solved.
+func runPuzzle(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("puzzle", flag.ContinueOnError)
+	day := fs.Int("day", 0, "Day to show")
+	fs.StringVar(&puzzlesDir, "dir", puzzlesDir, "Directory to cache dayN.md in")
+	session := fs.String("session", os.Getenv("AOC_SESSION"), "adventofcode.com session cookie, needed for part 2 (default $AOC_SESSION)")
+	refresh := fs.Bool("refresh", false, "Download the statement again unless the cached copy has both parts")
+	force := fs.Bool("force", false, "Try a day that has not unlocked yet")
+	if err := parseSubcommand(fs, args); err != nil {
+		return err
+	}
+	if *day == 0 {
+		return errors.New("puzzle needs -day")
+	}
+	if err := checkUnlocked(*day, *force); err != nil {
+		return err
+	}
+
+	text, err := fetch.EnsurePuzzle(puzzlePath(*day), *day, *session, *refresh)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, text)
+	return err
+}
+
+func puzzlePath(day int) string {
+	return filepath.Join(puzzlesDir, fmt.Sprintf("day%d.md", day))
+}
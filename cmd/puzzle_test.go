@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunPuzzleNeedsDay(t *testing.T) {
+	if err := runPuzzle(&bytes.Buffer{}, nil); err == nil || !strings.Contains(err.Error(), "-day") {
+		t.Errorf("expected a missing-day error, got %v", err)
+	}
+}
+
+func TestRunPuzzlePrintsCachedStatement(t *testing.T) {
+	saved := puzzlesDir
+	t.Cleanup(func() { puzzlesDir = saved })
+
+	dir := t.TempDir()
+	statement := "## --- Day 1: Secret Entrance ---\n\nTurn the dial.\n\n## --- Part Two ---\n\nCount every click.\n"
+	if err := os.WriteFile(filepath.Join(dir, "day1.md"), []byte(statement), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// both parts are cached, so nothing is downloaded
+	var out bytes.Buffer
+	if err := runPuzzle(&out, []string{"-day", "1", "-dir", dir, "-session", ""}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != statement {
+		t.Errorf("expected the cached statement, got %q", out.String())
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Reporter renders solver results in one output format. runAll calls Start
+// once, Result after each solver finishes, and Finish with the total time.
+type Reporter interface {
+	Start()
+	Result(r result)
+	Finish(total time.Duration) error
+}
+
+// newReporter returns the reporter for an -output format
+func newReporter(format string, w io.Writer, summaryOnly bool) (Reporter, error) {
+	switch format {
+	case "text":
+		return &textReporter{w: w, summaryOnly: summaryOnly}, nil
+	case "json":
+		return &jsonReporter{w: w}, nil
+	case "csv":
+		return &csvReporter{w: csv.NewWriter(w)}, nil
+	case "tsv":
+		tw := csv.NewWriter(w)
+		tw.Comma = '\t'
+		return &csvReporter{w: tw}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q (want text, json, csv, or tsv)", format)
+}
+
+// checkSummaryOnly rejects -summary-only alongside the reporters that ignore
+// it: only the text report has a final table to keep
+func checkSummaryOnly(summaryOnly bool, format string, quiet, verify bool) error {
+	if !summaryOnly {
+		return nil
+	}
+	switch {
+	case verify:
+		return errors.New("-summary-only and -verify cannot be combined; -verify prints its own report")
+	case quiet:
+		return errors.New("-summary-only and -quiet cannot be combined; -quiet prints only the answers")
+	case format != "text":
+		return fmt.Errorf("-summary-only cannot be combined with -output %s; it only shortens the text report", format)
+	}
+	return nil
+}
+
+// openOutput opens the -out file for the report, or stdout when path is empty
+func openOutput(path string) (io.WriteCloser, error) {
+	if path == "" {
+		return nopCloser{os.Stdout}, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating output file: %w", err)
+	}
+	return f, nil
+}
+
+// nopCloser keeps stdout open when the report is written there
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// textReporter is the human-readable output: a line per solver or, in
+// summary-only mode, a compact table of answers once everything has finished
+type textReporter struct {
+	w           io.Writer
+	summaryOnly bool
+	results     []result
+}
+
+func (t *textReporter) Start() {
+	if !t.summaryOnly {
+		printHeader(t.w)
+	}
+}
+
+func (t *textReporter) Result(r result) {
+	if t.summaryOnly {
+		t.results = append(t.results, r)
+		return
+	}
+	printResult(t.w, r)
+}
+
+func (t *textReporter) Finish(total time.Duration) error {
+	if t.summaryOnly {
+		printSummary(t.w, t.results)
+		printTimings(t.w, t.results)
+	}
+	_, err := fmt.Fprintf(t.w, "\n%sTotal time: %v\n", sym.clock, sym.duration(total))
+	return err
+}
+
+func printResult(w io.Writer, r result) {
+	if r.err != nil {
+		fmt.Fprintf(w, "%sDay %d Part %d: %v\n", sym.fail, r.day, r.part, r.err)
+	} else {
+		fmt.Fprintf(w, "%sDay %d Part %d: %d (%s)\n", sym.ok, r.day, r.part, r.value, timing(r))
+	}
+}
+
+// timing is a result's duration, or its percentiles when it ran under
+// -repeat, followed by its allocations under -mem
+func timing(r result) string {
+	if r.cached {
+		return "cached"
+	}
+	text := sym.duration(r.elapsed)
+	if len(r.repeats.samples) > 0 {
+		text = fmt.Sprintf("p50 %s, p90 %s, p99 %s over %d runs",
+			sym.duration(r.repeats.Percentile(50)),
+			sym.duration(r.repeats.Percentile(90)),
+			sym.duration(r.repeats.Percentile(99)),
+			len(r.repeats.samples))
+	}
+	if r.mem != nil {
+		text += "; " + r.mem.String()
+	}
+	return text
+}
+
+func printSummary(w io.Writer, results []result) {
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Fprintf(w, "%d.%d = error: %v\n", r.day, r.part, r.err)
+		} else {
+			fmt.Fprintf(w, "%d.%d = %d\n", r.day, r.part, r.value)
+		}
+	}
+}
+
+// printTimings lists the -repeat percentiles and -mem allocations below the
+// summary table. The lines carry no " = ", so grepping the table into
+// answers.txt skips them.
+func printTimings(w io.Writer, results []result) {
+	for _, r := range results {
+		if r.err == nil && (len(r.repeats.samples) > 0 || r.mem != nil) {
+			fmt.Fprintf(w, "%d.%d: %s\n", r.day, r.part, timing(r))
+		}
+	}
+}
+
+// quietReporter prints nothing but each answer on its own line, for piping
+// into other tools; failures go to errs so they cannot pass for an answer
+type quietReporter struct {
+	w, errs io.Writer
+}
+
+func (q *quietReporter) Start() {}
+
+func (q *quietReporter) Result(r result) {
+	if r.err != nil {
+		fmt.Fprintf(q.errs, "Day %d Part %d: %v\n", r.day, r.part, r.err)
+		return
+	}
+	fmt.Fprintln(q.w, r.value)
+}
+
+func (q *quietReporter) Finish(time.Duration) error { return nil }
+
+// jsonResult is one solver's entry in the JSON report. Result is zeroed and
+// Error set when the solve fails. Under -repeat, DurationMS is the median and
+// the percentiles are filled in; -mem adds the allocation counters.
+type jsonResult struct {
+	Day        int      `json:"day"`
+	Part       int      `json:"part"`
+	Result     int      `json:"result"`
+	DurationMS float64  `json:"duration_ms"`
+	Runs       int      `json:"runs,omitempty"`
+	P50MS      *float64 `json:"p50_ms,omitempty"`
+	P90MS      *float64 `json:"p90_ms,omitempty"`
+	P99MS      *float64 `json:"p99_ms,omitempty"`
+	AllocBytes *uint64  `json:"alloc_bytes,omitempty"`
+	Allocs     *uint64  `json:"allocs,omitempty"`
+	PeakHeap   *uint64  `json:"peak_heap_bytes,omitempty"`
+	Cached     bool     `json:"cached,omitempty"`
+	Error      *string  `json:"error"`
+}
+
+// jsonReport is the whole JSON document, written once every solver has run
+type jsonReport struct {
+	Results []jsonResult `json:"results"`
+	TotalMS float64      `json:"total_ms"`
+}
+
+type jsonReporter struct {
+	w      io.Writer
+	report jsonReport
+}
+
+func (j *jsonReporter) Start() {
+	j.report.Results = []jsonResult{}
+}
+
+func (j *jsonReporter) Result(r result) {
+	j.report.Results = append(j.report.Results, newJSONResult(r))
+}
+
+// newJSONResult converts a result to its JSON entry, as also served by serve
+func newJSONResult(r result) jsonResult {
+	entry := jsonResult{Day: r.day, Part: r.part, Result: r.value, DurationMS: milliseconds(r.elapsed), Cached: r.cached}
+	if r.err != nil {
+		msg := r.err.Error()
+		entry.Result, entry.Error = 0, &msg
+	} else if n := len(r.repeats.samples); n > 0 {
+		entry.Runs = n
+		entry.P50MS = percentileMS(r.repeats, 50)
+		entry.P90MS = percentileMS(r.repeats, 90)
+		entry.P99MS = percentileMS(r.repeats, 99)
+	}
+	if r.mem != nil && r.err == nil {
+		entry.AllocBytes, entry.Allocs, entry.PeakHeap = &r.mem.allocBytes, &r.mem.allocs, &r.mem.peakHeap
+	}
+	return entry
+}
+
+func (j *jsonReporter) Finish(total time.Duration) error {
+	j.report.TotalMS = milliseconds(total)
+	enc := json.NewEncoder(j.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(j.report)
+}
+
+// csvReporter writes a header row and then one row per solver; the tsv
+// format is the same reporter with tab-separated fields
+type csvReporter struct {
+	w *csv.Writer
+}
+
+func (c *csvReporter) Start() {
+	c.w.Write([]string{"day", "part", "result", "duration_ms", "error"})
+}
+
+func (c *csvReporter) Result(r result) {
+	value, errText := strconv.Itoa(r.value), ""
+	if r.err != nil {
+		value, errText = "", r.err.Error()
+	}
+	c.w.Write([]string{
+		strconv.Itoa(r.day),
+		strconv.Itoa(r.part),
+		value,
+		strconv.FormatFloat(milliseconds(r.elapsed), 'f', 3, 64),
+		errText,
+	})
+}
+
+func (c *csvReporter) Finish(time.Duration) error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func percentileMS(stats benchStats, p float64) *float64 {
+	ms := milliseconds(stats.Percentile(p))
+	return &ms
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleResults() []result {
+	return []result{
+		{day: 2, part: 1, value: 12345, elapsed: 1200 * time.Microsecond},
+		{day: 2, part: 2, value: 99, err: errors.New("boom")},
+	}
+}
+
+func report(t *testing.T, format string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	rep, err := newReporter(format, &out, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rep.Start()
+	for _, r := range sampleResults() {
+		rep.Result(r)
+	}
+	if err := rep.Finish(3 * time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return out.String()
+}
+
+func TestJSONReporter(t *testing.T) {
+	var got struct {
+		Results []struct {
+			Day, Part  int
+			Result     int
+			DurationMS float64 `json:"duration_ms"`
+			Error      *string
+		}
+		TotalMS float64 `json:"total_ms"`
+	}
+	if err := json.Unmarshal([]byte(report(t, "json")), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if len(got.Results) != 2 || got.TotalMS != 3 {
+		t.Fatalf("expected 2 results and a 3ms total, got %+v", got)
+	}
+	ok, failed := got.Results[0], got.Results[1]
+	if ok.Day != 2 || ok.Part != 1 || ok.Result != 12345 || ok.DurationMS != 1.2 || ok.Error != nil {
+		t.Errorf("unexpected successful entry %+v", ok)
+	}
+	if failed.Result != 0 || failed.Error == nil || *failed.Error != "boom" {
+		t.Errorf("failed entry should have a zero result and the error, got %+v", failed)
+	}
+}
+
+func TestJSONReporterRepeat(t *testing.T) {
+	var out bytes.Buffer
+	rep := &jsonReporter{w: &out}
+	rep.Start()
+	samples := []time.Duration{3 * time.Millisecond, time.Millisecond, 2 * time.Millisecond}
+	rep.Result(result{day: 1, part: 1, value: 5, elapsed: 2 * time.Millisecond, repeats: newBenchStats(samples)})
+	if err := rep.Finish(6 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Results []struct {
+			Runs  int     `json:"runs"`
+			P50MS float64 `json:"p50_ms"`
+			P99MS float64 `json:"p99_ms"`
+		}
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if e := got.Results[0]; e.Runs != 3 || e.P50MS != 2 || e.P99MS != 3 {
+		t.Errorf("expected 3 runs with p50 2ms and p99 3ms, got %+v", e)
+	}
+	if strings.Contains(report(t, "json"), "p50_ms") {
+		t.Error("single runs should not report percentiles")
+	}
+}
+
+func TestQuietReporter(t *testing.T) {
+	var out, errs bytes.Buffer
+	rep := &quietReporter{w: &out, errs: &errs}
+	rep.Start()
+	for _, r := range sampleResults() {
+		rep.Result(r)
+	}
+	if err := rep.Finish(time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := out.String(); got != "12345\n" {
+		t.Errorf("expected only the answer on stdout, got %q", got)
+	}
+	if got := errs.String(); got != "Day 2 Part 2: boom\n" {
+		t.Errorf("expected the failure on stderr, got %q", got)
+	}
+}
+
+func TestCSVReporter(t *testing.T) {
+	rows, err := csv.NewReader(bytes.NewBufferString(report(t, "csv"))).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"day", "part", "result", "duration_ms", "error"},
+		{"2", "1", "12345", "1.200", ""},
+		{"2", "2", "", "0.000", "boom"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %v", len(want), rows)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if rows[i][j] != want[i][j] {
+				t.Errorf("row %d column %d: expected %q, got %q", i, j, want[i][j], rows[i][j])
+			}
+		}
+	}
+}
+
+func TestTSVReporter(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(report(t, "tsv")), "\n")
+
+	want := []string{
+		"day\tpart\tresult\tduration_ms\terror",
+		"2\t1\t12345\t1.200\t",
+		"2\t2\t\t0.000\tboom",
+	}
+	if !slices.Equal(lines, want) {
+		t.Errorf("expected rows %q, got %q", want, lines)
+	}
+}
+
+func TestOpenOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.csv")
+	out, err := openOutput(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := io.WriteString(out, "day,part\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := os.ReadFile(path); err != nil || string(data) != "day,part\n" {
+		t.Errorf("expected the report in %s, got %q, %v", path, data, err)
+	}
+
+	if _, err := openOutput(filepath.Join(t.TempDir(), "missing", "results.csv")); err == nil {
+		t.Error("expected an error for an unwritable path")
+	}
+}
+
+func TestNewReporterRejectsUnknownFormat(t *testing.T) {
+	if _, err := newReporter("xml", &bytes.Buffer{}, false); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestCheckSummaryOnly(t *testing.T) {
+	tests := []struct {
+		name          string
+		summaryOnly   bool
+		format        string
+		quiet, verify bool
+		wantErr       bool
+	}{
+		{"text", true, "text", false, false, false},
+		{"json", true, "json", false, false, true},
+		{"csv", true, "csv", false, false, true},
+		{"tsv", true, "tsv", false, false, true},
+		{"quiet", true, "text", true, false, true},
+		{"verify", true, "text", false, true, true},
+		{"flag unset", false, "json", true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSummaryOnly(tt.summaryOnly, tt.format, tt.quiet, tt.verify)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkSummaryOnly() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
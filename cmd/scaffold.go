@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// scaffoldFS holds the templates for a new day package, modelled on the
+// hand-written day stubs
+//
+//go:embed scaffold/*.tmpl
+var scaffoldFS embed.FS
+
+var scaffoldTemplates = template.Must(template.ParseFS(scaffoldFS, "scaffold/*.tmpl"))
+
+// runNew generates the aoc/dayN package for "new N" and registers it with the
+// runner by adding its blank import to cmd/main.go
+func runNew(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	root := fs.String("root", ".", "Repository root holding go.mod")
+	if err := parseSubcommand(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: new [-root DIR] DAY")
+	}
+	day, err := strconv.Atoi(fs.Arg(0))
+	if err != nil || day < 1 {
+		return fmt.Errorf("invalid day %q", fs.Arg(0))
+	}
+
+	if _, err := os.Stat(filepath.Join(*root, "go.mod")); err != nil {
+		return fmt.Errorf("%s is not the repository root: %w", *root, err)
+	}
+	pkgDir := filepath.Join(*root, "aoc", fmt.Sprintf("day%d", day))
+	if _, err := os.Stat(pkgDir); err == nil {
+		return fmt.Errorf("%s already exists", pkgDir)
+	}
+
+	if err := scaffoldDay(pkgDir, day); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%sCreated %s\n", sym.created, pkgDir)
+
+	mainPath := filepath.Join(*root, "cmd", "main.go")
+	if err := registerDayImport(mainPath, day); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%sRegistered day %d in %s\n", sym.linked, day, mainPath)
+	return nil
+}
+
+// scaffoldDay writes the day package: registration, parser, both parts, an
+// example test and its empty testdata input
+func scaffoldDay(pkgDir string, day int) error {
+	files := []struct {
+		name, template string
+		part           int
+	}{
+		{fmt.Sprintf("day%d.go", day), "day.go.tmpl", 0},
+		{"parser.go", "parser.go.tmpl", 0},
+		{"part1.go", "part.go.tmpl", 1},
+		{"part2.go", "part.go.tmpl", 2},
+		{fmt.Sprintf("day%d_test.go", day), "test.go.tmpl", 0},
+	}
+
+	if err := os.MkdirAll(filepath.Join(pkgDir, "testdata"), 0o755); err != nil {
+		return fmt.Errorf("creating package: %w", err)
+	}
+	for _, f := range files {
+		var buf bytes.Buffer
+		data := struct{ Day, Part int }{day, f.part}
+		if err := scaffoldTemplates.ExecuteTemplate(&buf, f.template, data); err != nil {
+			return fmt.Errorf("rendering %s: %w", f.name, err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("formatting %s: %w", f.name, err)
+		}
+		if err := os.WriteFile(filepath.Join(pkgDir, f.name), src, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+	}
+
+	example := filepath.Join(pkgDir, "testdata", "example.txt")
+	if err := os.WriteFile(example, nil, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", example, err)
+	}
+	return nil
+}
+
+// registerDayImport adds the day's blank import after the last day import in
+// the runner's source, leaving the rest of the file untouched
+func registerDayImport(mainPath string, day int) error {
+	data, err := os.ReadFile(mainPath)
+	if err != nil {
+		return fmt.Errorf("registering day %d: %w", day, err)
+	}
+
+	importLine := fmt.Sprintf("\t_ \"adv2025/aoc/day%d\"", day)
+	lines := strings.Split(string(data), "\n")
+	last := -1
+	for i, line := range lines {
+		if line == importLine {
+			return nil
+		}
+		if strings.Contains(line, `"adv2025/aoc/day`) {
+			last = i
+		}
+	}
+	if last < 0 {
+		return fmt.Errorf("registering day %d: no day imports found in %s", day, mainPath)
+	}
+
+	lines = append(lines[:last+1], append([]string{importLine}, lines[last+1:]...)...)
+	if err := os.WriteFile(mainPath, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		return fmt.Errorf("registering day %d: %w", day, err)
+	}
+	return nil
+}
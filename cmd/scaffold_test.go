@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRoot creates a repository root with a go.mod and a runner importing day 1
+func fakeRoot(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	runner := "package main\n\nimport (\n\t\"fmt\"\n\n\tday1 \"adv2025/aoc/day1\"\n\t_ \"adv2025/aoc/day2\"\n)\n"
+	if err := os.Mkdir(filepath.Join(root, "cmd"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range map[string]string{
+		"go.mod":                        "module adv2025\n",
+		filepath.Join("cmd", "main.go"): runner,
+	} {
+		if err := os.WriteFile(filepath.Join(root, path), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestRunNew(t *testing.T) {
+	root := fakeRoot(t)
+	if err := runNew(&bytes.Buffer{}, []string{"-root", root, "13"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pkgDir := filepath.Join(root, "aoc", "day13")
+	fset := token.NewFileSet()
+	for _, name := range []string{"day13.go", "parser.go", "part1.go", "part2.go", "day13_test.go"} {
+		f, err := parser.ParseFile(fset, filepath.Join(pkgDir, name), nil, 0)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if f.Name.Name != "day13" {
+			t.Errorf("%s: expected package day13, got %s", name, f.Name.Name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(pkgDir, "testdata", "example.txt")); err != nil {
+		t.Errorf("expected an example input stub: %v", err)
+	}
+
+	day, _ := os.ReadFile(filepath.Join(pkgDir, "day13.go"))
+	if !strings.Contains(string(day), "registry.Register(13, i+1, part)") || !strings.Contains(string(day), "registry.MarkStub(13, i+1)") {
+		t.Errorf("day13.go should register day 13 as a stub:\n%s", day)
+	}
+
+	runner, _ := os.ReadFile(filepath.Join(root, "cmd", "main.go"))
+	if !strings.Contains(string(runner), "\t_ \"adv2025/aoc/day2\"\n\t_ \"adv2025/aoc/day13\"\n)") {
+		t.Errorf("expected the day 13 import after day 2:\n%s", runner)
+	}
+
+	if err := runNew(&bytes.Buffer{}, []string{"-root", root, "13"}); err == nil {
+		t.Error("expected an error when the package already exists")
+	}
+}
+
+func TestRunNewRejectsBadArguments(t *testing.T) {
+	root := fakeRoot(t)
+	for _, args := range [][]string{{"-root", root}, {"-root", root, "zero"}, {"-root", root, "0"}, {"-root", t.TempDir(), "13"}} {
+		if err := runNew(&bytes.Buffer{}, args); err == nil {
+			t.Errorf("runNew(%q): expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"adv2025/aoc/fetch"
+)
+
+// now is the clock the unlock checks use; tests move it around the event
+var now = time.Now
+
+// checkUnlocked refuses a day whose puzzle is not out yet, unless force
+func checkUnlocked(day int, force bool) error {
+	if force {
+		return nil
+	}
+	if err := fetch.CheckUnlocked(day, now()); err != nil {
+		return fmt.Errorf("%w; pass -force to go ahead anyway", err)
+	}
+	return nil
+}
+
+// runNext prints a countdown to the next puzzle unlock
+func runNext(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("next", flag.ContinueOnError)
+	if err := parseSubcommand(fs, args); err != nil {
+		return err
+	}
+
+	t := now()
+	day, ok := fetch.Next(t)
+	if !ok {
+		_, err := fmt.Fprintf(w, "%sAll %d puzzles are unlocked; the last went live %s\n",
+			sym.star, fetch.Days, fetch.UnlockTime(fetch.Days).Format("Mon Jan 2 2006 15:04 MST"))
+		return err
+	}
+
+	unlock := fetch.UnlockTime(day)
+	_, err := fmt.Fprintf(w, "%sDay %d unlocks in %s (%s, %s)\n", sym.clock, day,
+		formatCountdown(unlock.Sub(t)), unlock.Format("Mon Jan 2 15:04 MST"), unlock.Local().Format("15:04 MST"))
+	return err
+}
+
+// formatCountdown renders d as "1d 02h 03m 04s", dropping leading zero units
+func formatCountdown(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours, minutes, seconds := d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %02dh %02dm %02ds", days, hours, minutes, seconds)
+	case hours > 0:
+		return fmt.Sprintf("%dh %02dm %02ds", hours, minutes, seconds)
+	case minutes > 0:
+		return fmt.Sprintf("%dm %02ds", minutes, seconds)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
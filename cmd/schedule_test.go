@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"adv2025/aoc/fetch"
+)
+
+// setNow freezes the unlock clock at t for the duration of the test
+func setNow(t *testing.T, at time.Time) {
+	t.Helper()
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = time.Now })
+}
+
+func TestCheckUnlocked(t *testing.T) {
+	setNow(t, fetch.UnlockTime(5).Add(-time.Hour))
+
+	if err := checkUnlocked(5, false); !errors.Is(err, fetch.ErrLocked) || !strings.Contains(err.Error(), "-force") {
+		t.Errorf("expected day 5 locked with a hint about -force, got %v", err)
+	}
+	if err := checkUnlocked(5, true); err != nil {
+		t.Errorf("-force should skip the check, got %v", err)
+	}
+	if err := checkUnlocked(4, false); err != nil {
+		t.Errorf("day 4 is out, got %v", err)
+	}
+}
+
+func TestRunNext(t *testing.T) {
+	setNow(t, fetch.UnlockTime(1).Add(-(26*time.Hour + 3*time.Minute + 4*time.Second)))
+
+	var out bytes.Buffer
+	if err := runNext(&out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.Contains(got, "Day 1 unlocks in 1d 02h 03m 04s (Mon Dec 1 00:00 EST") {
+		t.Errorf("unexpected countdown:\n%s", got)
+	}
+
+	setNow(t, fetch.UnlockTime(fetch.Days).Add(time.Hour))
+	out.Reset()
+	if err := runNext(&out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.Contains(got, "All 12 puzzles are unlocked") {
+		t.Errorf("expected the event to be over:\n%s", got)
+	}
+}
+
+func TestFormatCountdown(t *testing.T) {
+	tests := map[time.Duration]string{
+		4 * time.Second:                      "4s",
+		3*time.Minute + 4*time.Second:        "3m 04s",
+		2*time.Hour + 4*time.Second:          "2h 00m 04s",
+		49*time.Hour + 1500*time.Millisecond: "2d 01h 00m 02s",
+	}
+	for d, want := range tests {
+		if got := formatCountdown(d); got != want {
+			t.Errorf("formatCountdown(%v): expected %q, got %q", d, want, got)
+		}
+	}
+}
+
+func TestRunFetchSkipsLockedDays(t *testing.T) {
+	withInputs(t)
+	saved := inputsDir
+	t.Cleanup(func() { inputsDir = saved })
+	setNow(t, time.Date(2025, time.November, 30, 0, 0, 0, 0, time.UTC))
+
+	if err := runFetch(&bytes.Buffer{}, []string{"-day", "1", "-session", "cookie"}); !errors.Is(err, fetch.ErrLocked) {
+		t.Errorf("expected an explicit locked day to be refused, got %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runFetch(&out, []string{"-session", "cookie"}); err != nil {
+		t.Fatalf("locked days should be skipped, not failed: %v", err)
+	}
+	if got := out.String(); strings.Count(got, "skipped, unlocks") != 12 {
+		t.Errorf("expected every day skipped:\n%s", got)
+	}
+}
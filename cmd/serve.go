@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"adv2025/aoc/registry"
+)
+
+// runServe serves the registered solvers over HTTP:
+//
+//	GET  /solvers                   every registered day and part
+//	POST /solve/{day}/{part}        solve the puzzle input in the request body
+//	POST /solve/{day}/{part}/stream the same, as newline-delimited events
+//
+// Answers use the same JSON entries as -output json. The stream sends a
+// progress event after each step of a part registered with
+// registry.RegisterProgress, then one result event. With -grpc-addr it also
+// serves the same calls as the gRPC service of proto/aoc/v1/solver.proto.
+func runServe(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8080", "Address to listen on; use :8080 to accept connections from other machines")
+	grpcAddr := fs.String("grpc-addr", "", "Also serve the gRPC Solver service on this address, such as localhost:9090")
+	maxInput := fs.Int64("max-input", 4<<20, "Largest puzzle input accepted, in bytes")
+	if err := parseSubcommand(fs, args); err != nil {
+		return err
+	}
+
+	// whichever server stops first ends the command
+	errc := make(chan error, 2)
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			return fmt.Errorf("listening for gRPC: %w", err)
+		}
+		fmt.Fprintf(w, "Serving gRPC on %s\n", lis.Addr())
+		go func() { errc <- newGRPCServer(w, *maxInput).Serve(lis) }()
+	}
+
+	fmt.Fprintf(w, "Serving %d solvers on http://%s\n", len(registry.All()), *addr)
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           logRequests(w, newServeMux(*maxInput)),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       time.Minute,
+		IdleTimeout:       2 * time.Minute,
+		// no WriteTimeout: a stream lasts as long as its solver
+	}
+	go func() { errc <- srv.ListenAndServe() }()
+	return <-errc
+}
+
+// solverInfo is one entry of GET /solvers
+type solverInfo struct {
+	Day  int  `json:"day"`
+	Part int  `json:"part"`
+	Stub bool `json:"stub"`
+}
+
+// errorResponse is the body of every failed request that never reached a solver
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func newServeMux(maxInput int64) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /solvers", func(w http.ResponseWriter, r *http.Request) {
+		infos := []solverInfo{}
+		for _, s := range registry.All() {
+			infos = append(infos, solverInfo{s.Day, s.Part, s.Stub})
+		}
+		writeJSON(w, http.StatusOK, infos)
+	})
+
+	mux.HandleFunc("POST /solve/{day}/{part}", func(w http.ResponseWriter, r *http.Request) {
+		s, input, ok := readSolveRequest(w, r, maxInput)
+		if !ok {
+			return
+		}
+
+		res := result{day: s.Day, part: s.Part}
+		start := time.Now()
+		res.value, res.err = s.SolveReader(bytes.NewReader(input))
+		res.elapsed = time.Since(start)
+
+		status := http.StatusOK
+		if res.err != nil {
+			status = http.StatusUnprocessableEntity
+		}
+		writeJSON(w, status, newJSONResult(res))
+	})
+
+	mux.HandleFunc("POST /solve/{day}/{part}/stream", func(w http.ResponseWriter, r *http.Request) {
+		s, input, ok := readSolveRequest(w, r, maxInput)
+		if !ok {
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		rc := http.NewResponseController(w)
+		enc := json.NewEncoder(w)
+		send := func(ev streamEvent) {
+			if err := enc.Encode(ev); err != nil {
+				log.Printf("serve: writing event: %v", err)
+			}
+			if err := rc.Flush(); err != nil {
+				log.Printf("serve: flushing event: %v", err)
+			}
+		}
+
+		res := result{day: s.Day, part: s.Part}
+		start := time.Now()
+		res.value, res.err = solveStreaming(s, input, func(done, total int) {
+			send(streamEvent{Progress: &streamProgress{done, total}})
+		})
+		res.elapsed = time.Since(start)
+		jr := newJSONResult(res)
+		send(streamEvent{Result: &jr})
+	})
+
+	return mux
+}
+
+// streamEvent is one line of POST /solve/{day}/{part}/stream: either a
+// progress update or the final result
+type streamEvent struct {
+	Progress *streamProgress `json:"progress,omitempty"`
+	Result   *jsonResult     `json:"result,omitempty"`
+}
+
+type streamProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// readSolveRequest resolves the day and part of a solve request and reads its
+// input, answering the request itself when either fails
+func readSolveRequest(w http.ResponseWriter, r *http.Request, maxInput int64) (registry.Solver, []byte, bool) {
+	day, dayErr := strconv.Atoi(r.PathValue("day"))
+	part, partErr := strconv.Atoi(r.PathValue("part"))
+	if dayErr != nil || partErr != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{"day and part must be numbers"})
+		return registry.Solver{}, nil, false
+	}
+
+	s, ok := readerSolver(day, part)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorResponse{fmt.Sprintf("no solver for day %d part %d", day, part)})
+		return registry.Solver{}, nil, false
+	}
+
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxInput))
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{fmt.Sprintf("input over %d bytes", tooLarge.Limit)})
+		return registry.Solver{}, nil, false
+	case err != nil:
+		writeJSON(w, http.StatusBadRequest, errorResponse{fmt.Sprintf("reading input: %v", err)})
+		return registry.Solver{}, nil, false
+	}
+	return s, input, true
+}
+
+// solveStreaming runs s on input, through its progress variant when it has
+// one. That variant reads a file, so the input is staged in a temporary one.
+func solveStreaming(s registry.Solver, input []byte, progress registry.Progress) (int, error) {
+	if s.SolveProgress == nil {
+		return s.SolveReader(bytes.NewReader(input))
+	}
+
+	f, err := os.CreateTemp("", "aoc-input-*")
+	if err != nil {
+		return 0, fmt.Errorf("staging input: %w", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(input)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("staging input: %w", err)
+	}
+	return s.SolveProgress(f.Name(), progress)
+}
+
+// readerSolver finds the registered part that can solve from an in-memory input
+func readerSolver(day, part int) (registry.Solver, bool) {
+	for _, s := range registry.All() {
+		if s.Day == day && s.Part == part && s.SolveReader != nil {
+			return s, true
+		}
+	}
+	return registry.Solver{}, false
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("serve: writing response: %v", err)
+	}
+}
+
+// statusRecorder remembers the status code written through it, for logRequests
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Flush passes flushes through, so streamed events leave as they are written
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.NewResponseController reach the underlying writer
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// logRequests writes a line per request to w with its status and duration
+func logRequests(w io.Writer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: rw, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(rec, r)
+		fmt.Fprintf(w, "%s %s %d (%s)\n", r.Method, r.URL.Path, rec.status, sym.duration(time.Since(start)))
+	})
+}
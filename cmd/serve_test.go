@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"adv2025/aoc/registry"
+)
+
+func serve(t *testing.T, method, path, body string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	var log bytes.Buffer
+	logRequests(&log, newServeMux(64)).ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	if !strings.Contains(log.String(), path) {
+		t.Errorf("expected the request logged, got %q", log.String())
+	}
+	return rec.Code, rec.Body.String()
+}
+
+func TestServeSolve(t *testing.T) {
+	code, body := serve(t, "POST", "/solve/1/2", "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\n")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", code, body)
+	}
+
+	var got jsonResult
+	if err := json.Unmarshal([]byte(body), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Day != 1 || got.Part != 2 || got.Result != 6 || got.Error != nil {
+		t.Errorf("expected day 1 part 2 = 6, got %+v", got)
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	tests := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"POST", "/solve/x/1", "", http.StatusBadRequest},
+		{"POST", "/solve/30/1", "", http.StatusNotFound},
+		{"POST", "/solve/1/1", strings.Repeat("L1\n", 100), http.StatusRequestEntityTooLarge},
+		{"POST", "/solve/1/1", "X5\n", http.StatusUnprocessableEntity},
+		{"GET", "/solve/1/1", "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		if code, body := serve(t, tt.method, tt.path, tt.body); code != tt.want {
+			t.Errorf("%s %s: expected %d, got %d: %s", tt.method, tt.path, tt.want, code, body)
+		}
+	}
+}
+
+func TestServeSolvers(t *testing.T) {
+	code, body := serve(t, "GET", "/solvers", "")
+	var infos []solverInfo
+	if err := json.Unmarshal([]byte(body), &infos); err != nil || code != http.StatusOK {
+		t.Fatalf("expected a JSON list, got %d: %s", code, body)
+	}
+	if len(infos) != len(solvers) {
+		t.Errorf("expected %d solvers, got %d", len(solvers), len(infos))
+	}
+}
+
+func TestServeSolveStream(t *testing.T) {
+	const input = "11-22,95-115,998-1012\n"
+	code, body := serve(t, "POST", "/solve/2/2/stream", input)
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", code, body)
+	}
+
+	var events []streamEvent
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		var ev streamEvent
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("invalid event %q: %v", line, err)
+		}
+		events = append(events, ev)
+	}
+	if len(events) != 4 {
+		t.Fatalf("expected a progress event per range and a result, got %s", body)
+	}
+	for i, ev := range events[:3] {
+		if ev.Progress == nil || *ev.Progress != (streamProgress{i + 1, 3}) {
+			t.Errorf("event %d: expected progress %d/3, got %+v", i, i+1, ev)
+		}
+	}
+
+	_, plain := serve(t, "POST", "/solve/2/2", input)
+	var want jsonResult
+	if err := json.Unmarshal([]byte(plain), &want); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	got := events[3].Result
+	if got == nil || got.Result != want.Result || got.Error != nil {
+		t.Errorf("expected the result %d of POST /solve/2/2, got %+v", want.Result, got)
+	}
+}
+
+func TestServeSolveStreamWithoutProgress(t *testing.T) {
+	code, body := serve(t, "POST", "/solve/1/2/stream", "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\n")
+	var ev streamEvent
+	if err := json.Unmarshal([]byte(body), &ev); err != nil || code != http.StatusOK {
+		t.Fatalf("expected a single result event, got %d: %s", code, body)
+	}
+	if ev.Progress != nil || ev.Result == nil || ev.Result.Result != 6 {
+		t.Errorf("expected day 1 part 2 = 6, got %+v", ev)
+	}
+
+	if code, body := serve(t, "POST", "/solve/30/1/stream", ""); code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown part, got %d: %s", code, body)
+	}
+}
+
+func TestServeSolveStreamFlushesProgress(t *testing.T) {
+	// day 95 reports progress, then waits for the client to have read it
+	release := make(chan struct{})
+	registry.Register(95, 1, func(string) (int, error) { return 0, nil })
+	registry.RegisterReader(95, 1, func(io.Reader) (int, error) { return 0, nil })
+	registry.RegisterProgress(95, 1, func(_ string, progress registry.Progress) (int, error) {
+		progress(1, 2)
+		select {
+		case <-release:
+			return 7, nil
+		case <-time.After(5 * time.Second):
+			return 0, nil
+		}
+	})
+	t.Cleanup(func() { registry.Unregister(95, 1) })
+
+	srv := httptest.NewServer(logRequests(io.Discard, newServeMux(64)))
+	defer srv.Close()
+	resp, err := http.Post(srv.URL+"/solve/95/1/stream", "text/plain", strings.NewReader("x\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	lines := bufio.NewScanner(resp.Body)
+	var ev streamEvent
+	if !lines.Scan() || json.Unmarshal(lines.Bytes(), &ev) != nil || ev.Progress == nil {
+		t.Fatalf("expected a progress event first, got %q", lines.Text())
+	}
+	close(release)
+	if !lines.Scan() || json.Unmarshal(lines.Bytes(), &ev) != nil || ev.Result == nil || ev.Result.Result != 7 {
+		t.Errorf("expected the result once released, got %q", lines.Text())
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// inputSet is one named input of a day and, optionally, its expected answers
+type inputSet struct {
+	File    string      `json:"file"`    // relative to -inputs-dir
+	Answers map[int]int `json:"answers"` // part -> answer
+}
+
+// manifest lists the named input sets of each day, as stored in
+// inputs/sets.json:
+//
+//	{"3": {"example1": {"file": "day3_example1.txt", "answers": {"1": 357}},
+//	       "example2": {"file": "day3_example2.txt", "answers": {"1": 98}}}}
+type manifest map[int]map[string]inputSet
+
+// manifestPath is where -set looks for the manifest
+func manifestPath() string {
+	return filepath.Join(inputsDir, "sets.json")
+}
+
+// loadManifest reads the input set manifest from path
+func loadManifest(path string) (manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading input sets: %w", err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing input sets %s: %w", path, err)
+	}
+	for day, sets := range m {
+		for name, set := range sets {
+			if set.File == "" {
+				return nil, fmt.Errorf("input sets %s: day %d set %q has no file", path, day, name)
+			}
+		}
+	}
+	return m, nil
+}
+
+// names lists every set name in the manifest, for error messages
+func (m manifest) names() []string {
+	var names []string
+	for _, sets := range m {
+		for name := range sets {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// selectSet narrows toRun to the days that define the named set, points each
+// of them at the set's file (unless -input already did), and returns the
+// set's expected answers for -verify
+func (m manifest) selectSet(name string, toRun []solver) ([]solver, answers, error) {
+	var selected []solver
+	known := make(answers)
+	for _, s := range toRun {
+		set, ok := m[s.day][name]
+		if !ok {
+			continue
+		}
+		selected = append(selected, s)
+
+		if _, overridden := inputOverrides.lookup(s.day, s.part); !overridden {
+			inputOverrides.set(inputKey{day: s.day}, filepath.Join(inputsDir, set.File))
+		}
+		if want, ok := set.Answers[s.part]; ok {
+			if known[s.day] == nil {
+				known[s.day] = make(map[int]int)
+			}
+			known[s.day][s.part] = want
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, nil, fmt.Errorf("no selected day has an input set %q (known sets: %s)", name, strings.Join(m.names(), ", "))
+	}
+	return selected, known, nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeManifest(t *testing.T, text string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "sets.json")
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSelectSet(t *testing.T) {
+	setInputs(t, 0, 0, "3.2=mine.txt")
+	saved := inputsDir
+	t.Cleanup(func() { inputsDir = saved })
+	inputsDir = "/srv/aoc"
+
+	m, err := loadManifest(writeManifest(t, `{
+		"3": {"example1": {"file": "day3_example1.txt", "answers": {"1": 357, "2": 3121910778619}},
+		      "example2": {"file": "day3_example2.txt"}},
+		"4": {"example2": {"file": "day4_example2.txt", "answers": {"1": 13}}}
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	toRun := []solver{{day: 3, part: 1}, {day: 3, part: 2}, {day: 4, part: 1}}
+	selected, known, err := m.selectSet("example1", toRun)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(selected) != 2 || selected[0].day != 3 || selected[1].day != 3 {
+		t.Fatalf("expected only day 3 to run, got %v", selected)
+	}
+	if got := inputPath(3, 1); got != filepath.Join("/srv/aoc", "day3_example1.txt") {
+		t.Errorf("expected the set's file for day 3 part 1, got %q", got)
+	}
+	if got := inputPath(3, 2); got != "mine.txt" {
+		t.Errorf("-input should win over the set, got %q", got)
+	}
+	if want, ok := known.lookup(3, 2); !ok || want != 3121910778619 {
+		t.Errorf("expected the set's answer for day 3 part 2, got %d (%v)", want, ok)
+	}
+	if _, ok := known.lookup(4, 1); ok {
+		t.Error("day 4 answers belong to another set")
+	}
+}
+
+func TestSelectSetUnknown(t *testing.T) {
+	setInputs(t, 0, 0)
+	m, err := loadManifest(writeManifest(t, `{"3": {"example1": {"file": "a.txt"}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := m.selectSet("real", []solver{{day: 3, part: 1}}); err == nil {
+		t.Error("expected an error for a set no day defines")
+	}
+}
+
+func TestLoadManifestErrors(t *testing.T) {
+	for _, bad := range []string{`{"3": {"example1": {}}}`, `{"x": {}}`, `[`} {
+		if _, err := loadManifest(writeManifest(t, bad)); err == nil {
+			t.Errorf("loadManifest(%s): expected an error", bad)
+		}
+	}
+	if _, err := loadManifest(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing manifest")
+	}
+}
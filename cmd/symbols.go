@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// symbols are the decorations the runner prints in front of its messages.
+// Each includes its trailing space, so the plain set can drop a symbol
+// entirely without leaving a gap.
+type symbols struct {
+	title    string // runner header
+	ok, fail string // solver results
+	pass     string // -verify: matching answer
+	wrong    string // -verify: wrong or missing answer
+	skip     string // -verify: no recorded answer
+	clock    string // total time
+	warn     string
+	download string // fetch: saved an input
+	present  string // fetch: input already on disk
+	submit   string
+	star     string // submit: answer accepted
+	created  string // new: package written
+	linked   string // new: import added
+	watching string
+	rerun    string
+	spinner  []rune
+	micro    string // unit for microseconds in durations
+}
+
+var fancySymbols = symbols{
+	title:    "🎄 ",
+	ok:       "✅ ",
+	fail:     "❌ ",
+	pass:     "✔ ",
+	wrong:    "✘ ",
+	skip:     "– ",
+	clock:    "⏱️  ",
+	warn:     "⚠️  ",
+	download: "⬇️  ",
+	present:  "✔ ",
+	submit:   "📨 ",
+	star:     "⭐ ",
+	created:  "📁 ",
+	linked:   "🔗 ",
+	watching: "👀 ",
+	rerun:    "🔁 ",
+	spinner:  []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏"),
+	micro:    "µs",
+}
+
+// plainSymbols keep the output pure ASCII for logs and dumb terminals. Words
+// stand in where the symbol carried meaning; the rest are dropped.
+var plainSymbols = symbols{
+	ok:      "[ok] ",
+	fail:    "[error] ",
+	warn:    "warning: ",
+	spinner: []rune(`|/-\`),
+	micro:   "us",
+}
+
+// sym is the symbol set in use; -plain and NO_COLOR switch it to plainSymbols
+var sym = fancySymbols
+
+// defaultPlain honors the NO_COLOR convention (https://no-color.org): any
+// non-empty value asks for undecorated output
+func defaultPlain() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// duration formats d like time.Duration.String, with the set's microsecond unit
+func (s symbols) duration(d time.Duration) string {
+	return strings.Replace(d.String(), "µs", s.micro, 1)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPlainSymbolsAreASCII(t *testing.T) {
+	saved := sym
+	sym = plainSymbols
+	t.Cleanup(func() { sym = saved })
+
+	withInputs(t, 1)
+	toRun := []solver{
+		{day: 1, part: 1, solve: fixed(42)},
+		{day: 1, part: 2, solve: func(string) (int, error) { return 0, errors.New("boom") }},
+	}
+
+	var out bytes.Buffer
+	runAll(&textReporter{w: &out}, toRun)
+	runAll(&verifyReporter{w: &out, answers: answers{1: {1: 42, 2: 7}}}, toRun)
+
+	for i, b := range out.Bytes() {
+		if b >= utf8.RuneSelf {
+			t.Fatalf("non-ASCII byte at offset %d:\n%s", i, out.String())
+		}
+	}
+	for _, want := range []string{"[ok] Day 1 Part 1: 42", "[error] Day 1 Part 2: boom", "PASS Day 1 Part 1", "FAIL Day 1 Part 2"} {
+		if !bytes.Contains(out.Bytes(), []byte(want)) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestDefaultPlain(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if defaultPlain() {
+		t.Error("an empty NO_COLOR should not switch to plain output")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if !defaultPlain() {
+		t.Error("expected NO_COLOR=1 to switch to plain output")
+	}
+}
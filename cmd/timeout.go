@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"adv2025/aoc/registry"
+)
+
+var errTimedOut = errors.New("timed out")
+
+// contextSolvers returns the registered parts that honor cancellation
+func contextSolvers() map[[2]int]func(context.Context, string) (int, error) {
+	aware := make(map[[2]int]func(context.Context, string) (int, error))
+	for _, s := range registry.All() {
+		if s.SolveContext != nil {
+			aware[[2]int{s.Day, s.Part}] = s.SolveContext
+		}
+	}
+	return aware
+}
+
+// withTimeout bounds every solver in toRun to timeout. Parts found in aware
+// that no other wrapper has changed yet are handed the deadline and stop
+// early; the rest keep running in the background once abandoned, but the
+// runner moves on and reports them as timed out either way.
+func withTimeout(toRun []solver, timeout time.Duration, aware map[[2]int]func(context.Context, string) (int, error)) []solver {
+	bounded := make([]solver, len(toRun))
+	for i, s := range toRun {
+		solve, ok := aware[[2]int{s.day, s.part}]
+		if !ok || s.wrapped {
+			plain := s.solve
+			solve = func(_ context.Context, path string) (int, error) {
+				return plain(path)
+			}
+		}
+		bounded[i] = s.wrap(func(path string) (int, error) {
+			return solveWithin(timeout, solve, path)
+		})
+	}
+	return bounded
+}
+
+// solveWithin runs solve on path, giving up after timeout
+func solveWithin(timeout time.Duration, solve func(context.Context, string) (int, error), path string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	type outcome struct {
+		value int
+		err   error
+	}
+	done := make(chan outcome, 1) // buffered so an abandoned solver can still finish
+	go func() {
+		value, err := solve(ctx, path)
+		done <- outcome{value, err}
+	}()
+
+	select {
+	case o := <-done:
+		if errors.Is(o.err, context.DeadlineExceeded) {
+			return 0, fmt.Errorf("%w after %v", errTimedOut, timeout)
+		}
+		return o.value, o.err
+	case <-ctx.Done():
+		return 0, fmt.Errorf("%w after %v", errTimedOut, timeout)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	hang := func(string) (int, error) {
+		<-release
+		return 1, nil
+	}
+
+	cancelled := make(chan error, 1)
+	aware := map[[2]int]func(context.Context, string) (int, error){
+		{4, 2}: func(ctx context.Context, _ string) (int, error) {
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+			return 0, ctx.Err()
+		},
+	}
+
+	toRun := []solver{{day: 1, part: 1, solve: fixed(42)}, {day: 2, part: 1, solve: hang}, {day: 4, part: 2, solve: hang}}
+	bounded := withTimeout(toRun, 20*time.Millisecond, aware)
+
+	if got, err := bounded[0].solve(""); got != 42 || err != nil {
+		t.Errorf("fast solver: expected 42, got %d, %v", got, err)
+	}
+	for _, s := range bounded[1:] {
+		if _, err := s.solve(""); !errors.Is(err, errTimedOut) {
+			t.Errorf("day %d part %d: expected a timeout, got %v", s.day, s.part, err)
+		}
+	}
+	if err := <-cancelled; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("context-aware solver should see the deadline, got %v", err)
+	}
+}
+
+func TestContextSolversIncludesDay4(t *testing.T) {
+	if _, ok := contextSolvers()[[2]int{4, 2}]; !ok {
+		t.Error("expected day 4 part 2 to register a context-aware solver")
+	}
+}
+
+func TestWithTimeoutKeepsEarlierWrappers(t *testing.T) {
+	cpu := filepath.Join(t.TempDir(), "cpu.pprof")
+	aware := map[[2]int]func(context.Context, string) (int, error){
+		{4, 2}: func(context.Context, string) (int, error) { return 0, errors.New("context variant used") },
+	}
+
+	// profiling first: the profiled solve must survive instead of the variant
+	profiled := withProfiles([]solver{{day: 4, part: 2, solve: fixed(42)}}, cpu, "")
+	bounded := withTimeout(profiled, time.Second, aware)
+	if got, err := bounded[0].solve(""); got != 42 || err != nil {
+		t.Fatalf("expected the profiled solver's result, got %d, %v", got, err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(cpu), "cpu.day4.part2.pprof")); err != nil {
+		t.Errorf("expected a CPU profile with -timeout: %v", err)
+	}
+
+	// so does -start's dial, were day 1 ever to take a context
+	withInputs(t)
+	if err := os.WriteFile(inputPathFor(1), []byte("R10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	aware[[2]int{1, 1}] = aware[[2]int{4, 2}]
+	started := withTimeout(withStart([]solver{{day: 1, part: 1, solve: fixed(0)}}, 90), time.Second, aware)
+	if got, err := started[0].solve(inputPathFor(1)); got != 1 || err != nil { // R10 from 90 lands on 0
+		t.Errorf("expected the -start solver's result, got %d, %v", got, err)
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// answers maps day -> part -> expected result, as stored in answers.json:
+//
+//	{"1": {"1": 1234, "2": 5678}}
+//
+// or in answers.txt, one DAY.PART ANSWER per line in the same shape as the
+// -summary-only output, so a trusted run can be saved as the baseline:
+//
+//	1.1 = 1234
+//	1.2 = 5678
+type answers map[int]map[int]int
+
+// defaultAnswerFiles are tried in order when -answers is not given;
+// exampleAnswerFiles replace them under -example
+var (
+	defaultAnswerFiles = []string{"answers.json", "answers.txt"}
+	exampleAnswerFiles = []string{"example_answers.json", "example_answers.txt"}
+)
+
+// findAnswers returns path, or the first default answers file that exists
+func findAnswers(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	candidates := defaultAnswerFiles
+	if useExamples {
+		candidates = exampleAnswerFiles
+	}
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no answers file: create %s or pass -answers", strings.Join(candidates, " or "))
+}
+
+// loadAnswers reads the known answers from path: a .txt file holds one
+// answer per line, anything else is JSON
+func loadAnswers(path string) (answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading answers: %w", err)
+	}
+
+	if filepath.Ext(path) == ".txt" {
+		known, err := parseAnswerText(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("parsing answers %s: %w", path, err)
+		}
+		return known, nil
+	}
+
+	var known answers
+	if err := json.Unmarshal(data, &known); err != nil {
+		return nil, fmt.Errorf("parsing answers %s: %w", path, err)
+	}
+	return known, nil
+}
+
+// parseAnswerText parses "DAY.PART = ANSWER" lines; the "=" is optional, and
+// blank lines and lines starting with # are ignored
+func parseAnswerText(text string) (answers, error) {
+	known := make(answers)
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(strings.Replace(line, "=", " ", 1))
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected DAY.PART = ANSWER, got %q", i+1, line)
+		}
+		key, err := parseInputKey(fields[0])
+		if err != nil || key.part == 0 {
+			return nil, fmt.Errorf("line %d: invalid DAY.PART %q", i+1, fields[0])
+		}
+		value, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid answer %q", i+1, fields[1])
+		}
+
+		if known[key.day] == nil {
+			known[key.day] = make(map[int]int)
+		}
+		known[key.day][key.part] = value
+	}
+	return known, nil
+}
+
+// lookup returns the recorded answer for day and part, if any
+func (a answers) lookup(day, part int) (int, bool) {
+	want, ok := a[day][part]
+	return want, ok
+}
+
+// verifyReporter checks each result against the known answers, printing a
+// PASS or FAIL line per solver. Solvers that returned an error always fail;
+// the others without a recorded answer are skipped, or counted as failures in
+// strict mode.
+type verifyReporter struct {
+	w       io.Writer
+	answers answers
+	strict  bool
+
+	passed, failed, skipped int
+
+	// failed splits into wrong (mismatched or, in strict mode, missing
+	// answers) and errored (solvers that returned an error)
+	wrong, errored int
+}
+
+func (v *verifyReporter) Start() {
+	printHeader(v.w)
+}
+
+func (v *verifyReporter) Result(r result) {
+	want, known := v.answers.lookup(r.day, r.part)
+	switch {
+	case r.err != nil:
+		v.failed++
+		v.errored++
+		fmt.Fprintf(v.w, "%sFAIL Day %d Part %d: %v\n", sym.wrong, r.day, r.part, r.err)
+	case !known && !v.strict:
+		v.skipped++
+		fmt.Fprintf(v.w, "%sSKIP Day %d Part %d: no recorded answer, skipped\n", sym.skip, r.day, r.part)
+	case !known:
+		v.failed++
+		v.wrong++
+		fmt.Fprintf(v.w, "%sFAIL Day %d Part %d: no recorded answer\n", sym.wrong, r.day, r.part)
+	case r.value != want:
+		v.failed++
+		v.wrong++
+		fmt.Fprintf(v.w, "%sFAIL Day %d Part %d: got %d, want %d (%v)\n", sym.wrong, r.day, r.part, r.value, want, sym.duration(r.elapsed))
+	default:
+		v.passed++
+		fmt.Fprintf(v.w, "%sPASS Day %d Part %d: %d (%v)\n", sym.pass, r.day, r.part, r.value, sym.duration(r.elapsed))
+	}
+}
+
+func (v *verifyReporter) Finish(total time.Duration) error {
+	_, err := fmt.Fprintf(v.w, "\n%d passed, %d failed, %d skipped\n%sTotal time: %v\n",
+		v.passed, v.failed, v.skipped, sym.clock, sym.duration(total))
+	return err
+}
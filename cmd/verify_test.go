@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadAnswers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	if err := os.WriteFile(path, []byte(`{"1":{"1":1234,"2":5678},"3":{"2":9}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	known, err := loadAnswers(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want, ok := known.lookup(1, 2); !ok || want != 5678 {
+		t.Errorf("expected day 1 part 2 = 5678, got %d (%v)", want, ok)
+	}
+	if _, ok := known.lookup(2, 1); ok {
+		t.Error("day 2 has no recorded answer")
+	}
+}
+
+func TestLoadAnswersText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.txt")
+	text := "# saved from -summary-only\n1.1 = 1234\n1.2 5678\n\n3.2 = 9\n"
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	known, err := loadAnswers(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tt := range []struct{ day, part, want int }{{1, 1, 1234}, {1, 2, 5678}, {3, 2, 9}} {
+		if got, ok := known.lookup(tt.day, tt.part); !ok || got != tt.want {
+			t.Errorf("expected day %d part %d = %d, got %d (%v)", tt.day, tt.part, tt.want, got, ok)
+		}
+	}
+}
+
+func TestParseAnswerTextErrors(t *testing.T) {
+	for _, bad := range []string{"1 = 5", "1.x = 5", "1.1 = five", "1.1 = 5 6", "1.1"} {
+		if _, err := parseAnswerText(bad); err == nil {
+			t.Errorf("parseAnswerText(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestFindAnswers(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if _, err := findAnswers(""); err == nil {
+		t.Error("expected an error with no answers file present")
+	}
+
+	if err := os.WriteFile("answers.txt", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := findAnswers(""); err != nil || got != "answers.txt" {
+		t.Errorf("expected answers.txt, got %q, %v", got, err)
+	}
+	if got, _ := findAnswers("mine.json"); got != "mine.json" {
+		t.Errorf("an explicit -answers path should win, got %q", got)
+	}
+}
+
+func TestFindAnswersExample(t *testing.T) {
+	t.Chdir(t.TempDir())
+	useExamples = true
+	t.Cleanup(func() { useExamples = false })
+
+	for _, name := range []string{"answers.json", "example_answers.txt"} {
+		if err := os.WriteFile(name, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, err := findAnswers(""); err != nil || got != "example_answers.txt" {
+		t.Errorf("expected the example answers under -example, got %q, %v", got, err)
+	}
+}
+
+func TestVerifyReporter(t *testing.T) {
+	known := answers{1: {1: 42, 2: 7}}
+	results := []result{
+		{day: 1, part: 1, value: 42},
+		{day: 1, part: 2, value: 8},
+		{day: 2, part: 1, value: 5},
+		{day: 2, part: 2, err: errors.New("boom")},
+	}
+
+	for _, tc := range []struct {
+		strict          bool
+		failed, skipped int
+		want            []string
+	}{
+		{false, 2, 1, []string{"✔ PASS Day 1 Part 1: 42", "✘ FAIL Day 1 Part 2: got 8, want 7", "– SKIP Day 2 Part 1: no recorded answer, skipped", "✘ FAIL Day 2 Part 2: boom", "1 passed, 2 failed, 1 skipped"}},
+		{true, 3, 0, []string{"✘ FAIL Day 2 Part 1: no recorded answer", "✘ FAIL Day 2 Part 2: boom"}},
+	} {
+		var out bytes.Buffer
+		rep := &verifyReporter{w: &out, answers: known, strict: tc.strict}
+		rep.Start()
+		for _, r := range results {
+			rep.Result(r)
+		}
+		if err := rep.Finish(0); err != nil {
+			t.Fatal(err)
+		}
+
+		if rep.passed != 1 || rep.failed != tc.failed || rep.skipped != tc.skipped {
+			t.Errorf("strict=%v: expected 1/%d/%d passed/failed/skipped, got %d/%d/%d",
+				tc.strict, tc.failed, tc.skipped, rep.passed, rep.failed, rep.skipped)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("strict=%v: output missing %q:\n%s", tc.strict, want, out.String())
+			}
+		}
+	}
+}
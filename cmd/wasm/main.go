@@ -0,0 +1,72 @@
+//go:build js && wasm
+
+// Command wasm exposes the solvers to JavaScript as a global
+// solve(day, part, inputText) function. Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o cmd/wasm/aoc.wasm ./cmd/wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" cmd/wasm/
+//
+// and serve cmd/wasm (e.g. python3 -m http.server -d cmd/wasm) to use index.html.
+package main
+
+import (
+	"fmt"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"adv2025/aoc/registry"
+
+	_ "adv2025/aoc/day1"
+	_ "adv2025/aoc/day10"
+	_ "adv2025/aoc/day11"
+	_ "adv2025/aoc/day12"
+	_ "adv2025/aoc/day2"
+	_ "adv2025/aoc/day3"
+	_ "adv2025/aoc/day4"
+	_ "adv2025/aoc/day5"
+	_ "adv2025/aoc/day6"
+	_ "adv2025/aoc/day7"
+	_ "adv2025/aoc/day8"
+	_ "adv2025/aoc/day9"
+)
+
+func main() {
+	js.Global().Set("solve", js.FuncOf(solve))
+	js.Global().Set("solvers", js.FuncOf(listSolvers))
+	select {} // keep the functions callable
+}
+
+// solve(day, part, inputText) returns {answer, durationMs} or {error}.
+// Answers are strings, since some exceed JavaScript's safe integer range.
+func solve(_ js.Value, args []js.Value) any {
+	if len(args) != 3 {
+		return map[string]any{"error": "usage: solve(day, part, inputText)"}
+	}
+	day, part := args[0].Int(), args[1].Int()
+
+	for _, s := range registry.All() {
+		if s.Day != day || s.Part != part || s.SolveReader == nil {
+			continue
+		}
+		start := time.Now()
+		answer, err := s.SolveReader(strings.NewReader(args[2].String()))
+		if err != nil {
+			return map[string]any{"error": err.Error()}
+		}
+		return map[string]any{
+			"answer":     fmt.Sprint(answer),
+			"durationMs": float64(time.Since(start).Microseconds()) / 1000,
+		}
+	}
+	return map[string]any{"error": fmt.Sprintf("no solver for day %d part %d", day, part)}
+}
+
+// solvers() lists the registered parts as [{day, part, stub}]
+func listSolvers(js.Value, []js.Value) any {
+	var parts []any
+	for _, s := range registry.All() {
+		parts = append(parts, map[string]any{"day": s.Day, "part": s.Part, "stub": s.Stub})
+	}
+	return parts
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// watchInterval is how often -watch polls the watched files for changes
+var watchInterval = 500 * time.Millisecond
+
+// watchedFiles lists the day's Go sources and the inputs of the selected
+// solvers: the files whose changes should re-run them
+func watchedFiles(day int, toRun []solver) []string {
+	paths, _ := filepath.Glob(filepath.Join("aoc", fmt.Sprintf("day%d", day), "*.go"))
+	for _, s := range toRun {
+		paths = append(paths, inputPath(s.day, s.part))
+	}
+	slices.Sort(paths)
+	return slices.Compact(paths)
+}
+
+// snapshot records the modification time of each path; missing files get
+// the zero time, so creating one counts as a change
+func snapshot(paths []string) map[string]time.Time {
+	times := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			times[path] = info.ModTime()
+		} else {
+			times[path] = time.Time{}
+		}
+	}
+	return times
+}
+
+// waitForChange polls paths until one of them changes and returns its name
+func waitForChange(paths []string, since map[string]time.Time) string {
+	for {
+		time.Sleep(watchInterval)
+		now := snapshot(paths)
+		for _, path := range paths {
+			if !now[path].Equal(since[path]) {
+				return path
+			}
+		}
+	}
+}
+
+// runWatch re-runs the runner with args through "go run ./cmd" whenever a
+// watched file changes. Going through the toolchain picks up edits to the
+// solution itself, which a running binary never could. It only returns on
+// error; stop it with Ctrl-C.
+func runWatch(w io.Writer, args, paths []string) error {
+	if _, err := exec.LookPath("go"); err != nil {
+		return fmt.Errorf("-watch rebuilds with the go tool: %w", err)
+	}
+
+	for {
+		since := snapshot(paths)
+
+		cmd := exec.Command("go", append([]string{"run", "./cmd"}, args...)...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, w, w
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(w, "%srun failed: %v\n", sym.warn, err)
+		}
+
+		fmt.Fprintf(w, "\n%sWatching %d files (Ctrl-C to stop)...\n", sym.watching, len(since))
+		changed := waitForChange(paths, since)
+		fmt.Fprintf(w, "\n%s%s changed, re-running\n%s\n", sym.rerun, changed, strings.Repeat("=", 50))
+	}
+}
+
+// withoutFlag removes every -name / --name / -name=value from args, for
+// passing the remaining flags on to a child run
+func withoutFlag(args []string, name string) []string {
+	var kept []string
+	for _, arg := range args {
+		trimmed := strings.TrimLeft(arg, "-")
+		if arg != trimmed && (trimmed == name || strings.HasPrefix(trimmed, name+"=")) {
+			continue
+		}
+		kept = append(kept, arg)
+	}
+	return kept
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWithoutFlag(t *testing.T) {
+	args := []string{"-day", "3", "-watch", "--watch", "-watch=true", "-watchful", "-part=1"}
+	want := []string{"-day", "3", "-watchful", "-part=1"}
+	if got := withoutFlag(args, "watch"); !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWatchedFiles(t *testing.T) {
+	withInputs(t, 3)
+	if err := os.MkdirAll("aoc/day3", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"aoc/day3/part1.go", "aoc/day3/notes.md"} {
+		if err := os.WriteFile(name, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := watchedFiles(3, []solver{{day: 3, part: 1, solve: fixed(0)}, {day: 3, part: 2, solve: fixed(0)}})
+	want := []string{"aoc/day3/part1.go", "inputs/day3_input.txt"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWaitForChange(t *testing.T) {
+	old := watchInterval
+	watchInterval = time.Millisecond
+	t.Cleanup(func() { watchInterval = old })
+
+	withInputs(t)
+	paths := []string{"a.go", "missing.txt"}
+	if err := os.WriteFile("a.go", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	since := snapshot(paths)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		os.WriteFile("missing.txt", nil, 0o644)
+	}()
+	if changed := waitForChange(paths, since); changed != "missing.txt" {
+		t.Errorf("expected the newly created file to count as a change, got %q", changed)
+	}
+}
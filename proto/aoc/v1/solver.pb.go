@@ -0,0 +1,525 @@
+// Service contract for solving puzzles remotely, served by
+// `go run ./cmd serve -grpc-addr localhost:9090`. serve's HTTP routes mirror
+// it: GET /solvers is List, POST /solve/{day}/{part} is Solve, and
+// POST /solve/{day}/{part}/stream is SolveStream, one JSON SolveEvent per line.
+// The Go code beside this file is generated: run `buf generate` in proto/.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: aoc/v1/solver.proto
+
+package aocv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_aoc_v1_solver_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aoc_v1_solver_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_aoc_v1_solver_proto_rawDescGZIP(), []int{0}
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Parts         []*Part                `protobuf:"bytes,1,rep,name=parts,proto3" json:"parts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_aoc_v1_solver_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aoc_v1_solver_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_aoc_v1_solver_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListResponse) GetParts() []*Part {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+type Part struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           int32                  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	Part          int32                  `protobuf:"varint,2,opt,name=part,proto3" json:"part,omitempty"`
+	Stub          bool                   `protobuf:"varint,3,opt,name=stub,proto3" json:"stub,omitempty"` // registered with registry.MarkStub
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Part) Reset() {
+	*x = Part{}
+	mi := &file_aoc_v1_solver_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Part) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Part) ProtoMessage() {}
+
+func (x *Part) ProtoReflect() protoreflect.Message {
+	mi := &file_aoc_v1_solver_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Part.ProtoReflect.Descriptor instead.
+func (*Part) Descriptor() ([]byte, []int) {
+	return file_aoc_v1_solver_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Part) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *Part) GetPart() int32 {
+	if x != nil {
+		return x.Part
+	}
+	return 0
+}
+
+func (x *Part) GetStub() bool {
+	if x != nil {
+		return x.Stub
+	}
+	return false
+}
+
+type SolveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           int32                  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	Part          int32                  `protobuf:"varint,2,opt,name=part,proto3" json:"part,omitempty"`
+	Input         []byte                 `protobuf:"bytes,3,opt,name=input,proto3" json:"input,omitempty"` // the raw puzzle input
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SolveRequest) Reset() {
+	*x = SolveRequest{}
+	mi := &file_aoc_v1_solver_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SolveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveRequest) ProtoMessage() {}
+
+func (x *SolveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aoc_v1_solver_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveRequest.ProtoReflect.Descriptor instead.
+func (*SolveRequest) Descriptor() ([]byte, []int) {
+	return file_aoc_v1_solver_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SolveRequest) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *SolveRequest) GetPart() int32 {
+	if x != nil {
+		return x.Part
+	}
+	return 0
+}
+
+func (x *SolveRequest) GetInput() []byte {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+type SolveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Answer        int64                  `protobuf:"varint,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // empty on success
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SolveResponse) Reset() {
+	*x = SolveResponse{}
+	mi := &file_aoc_v1_solver_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SolveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveResponse) ProtoMessage() {}
+
+func (x *SolveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aoc_v1_solver_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveResponse.ProtoReflect.Descriptor instead.
+func (*SolveResponse) Descriptor() ([]byte, []int) {
+	return file_aoc_v1_solver_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SolveResponse) GetAnswer() int64 {
+	if x != nil {
+		return x.Answer
+	}
+	return 0
+}
+
+func (x *SolveResponse) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *SolveResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SolveEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*SolveEvent_Progress
+	//	*SolveEvent_Result
+	Event         isSolveEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SolveEvent) Reset() {
+	*x = SolveEvent{}
+	mi := &file_aoc_v1_solver_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SolveEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveEvent) ProtoMessage() {}
+
+func (x *SolveEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aoc_v1_solver_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveEvent.ProtoReflect.Descriptor instead.
+func (*SolveEvent) Descriptor() ([]byte, []int) {
+	return file_aoc_v1_solver_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SolveEvent) GetEvent() isSolveEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *SolveEvent) GetProgress() *Progress {
+	if x != nil {
+		if x, ok := x.Event.(*SolveEvent_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *SolveEvent) GetResult() *SolveResponse {
+	if x != nil {
+		if x, ok := x.Event.(*SolveEvent_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isSolveEvent_Event interface {
+	isSolveEvent_Event()
+}
+
+type SolveEvent_Progress struct {
+	Progress *Progress `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type SolveEvent_Result struct {
+	Result *SolveResponse `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*SolveEvent_Progress) isSolveEvent_Event() {}
+
+func (*SolveEvent_Result) isSolveEvent_Event() {}
+
+type Progress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Done          int64                  `protobuf:"varint,1,opt,name=done,proto3" json:"done,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_aoc_v1_solver_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_aoc_v1_solver_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_aoc_v1_solver_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Progress) GetDone() int64 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *Progress) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_aoc_v1_solver_proto protoreflect.FileDescriptor
+
+const file_aoc_v1_solver_proto_rawDesc = "" +
+	"\n" +
+	"\x13aoc/v1/solver.proto\x12\x06aoc.v1\x1a\x1egoogle/protobuf/duration.proto\"\r\n" +
+	"\vListRequest\"2\n" +
+	"\fListResponse\x12\"\n" +
+	"\x05parts\x18\x01 \x03(\v2\f.aoc.v1.PartR\x05parts\"@\n" +
+	"\x04Part\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x05R\x03day\x12\x12\n" +
+	"\x04part\x18\x02 \x01(\x05R\x04part\x12\x12\n" +
+	"\x04stub\x18\x03 \x01(\bR\x04stub\"J\n" +
+	"\fSolveRequest\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x05R\x03day\x12\x12\n" +
+	"\x04part\x18\x02 \x01(\x05R\x04part\x12\x14\n" +
+	"\x05input\x18\x03 \x01(\fR\x05input\"t\n" +
+	"\rSolveResponse\x12\x16\n" +
+	"\x06answer\x18\x01 \x01(\x03R\x06answer\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"v\n" +
+	"\n" +
+	"SolveEvent\x12.\n" +
+	"\bprogress\x18\x01 \x01(\v2\x10.aoc.v1.ProgressH\x00R\bprogress\x12/\n" +
+	"\x06result\x18\x02 \x01(\v2\x15.aoc.v1.SolveResponseH\x00R\x06resultB\a\n" +
+	"\x05event\"4\n" +
+	"\bProgress\x12\x12\n" +
+	"\x04done\x18\x01 \x01(\x03R\x04done\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total2\xac\x01\n" +
+	"\x06Solver\x121\n" +
+	"\x04List\x12\x13.aoc.v1.ListRequest\x1a\x14.aoc.v1.ListResponse\x124\n" +
+	"\x05Solve\x12\x14.aoc.v1.SolveRequest\x1a\x15.aoc.v1.SolveResponse\x129\n" +
+	"\vSolveStream\x12\x14.aoc.v1.SolveRequest\x1a\x12.aoc.v1.SolveEvent0\x01B\x1cZ\x1aadv2025/proto/aoc/v1;aocv1b\x06proto3"
+
+var (
+	file_aoc_v1_solver_proto_rawDescOnce sync.Once
+	file_aoc_v1_solver_proto_rawDescData []byte
+)
+
+func file_aoc_v1_solver_proto_rawDescGZIP() []byte {
+	file_aoc_v1_solver_proto_rawDescOnce.Do(func() {
+		file_aoc_v1_solver_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_aoc_v1_solver_proto_rawDesc), len(file_aoc_v1_solver_proto_rawDesc)))
+	})
+	return file_aoc_v1_solver_proto_rawDescData
+}
+
+var file_aoc_v1_solver_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_aoc_v1_solver_proto_goTypes = []any{
+	(*ListRequest)(nil),         // 0: aoc.v1.ListRequest
+	(*ListResponse)(nil),        // 1: aoc.v1.ListResponse
+	(*Part)(nil),                // 2: aoc.v1.Part
+	(*SolveRequest)(nil),        // 3: aoc.v1.SolveRequest
+	(*SolveResponse)(nil),       // 4: aoc.v1.SolveResponse
+	(*SolveEvent)(nil),          // 5: aoc.v1.SolveEvent
+	(*Progress)(nil),            // 6: aoc.v1.Progress
+	(*durationpb.Duration)(nil), // 7: google.protobuf.Duration
+}
+var file_aoc_v1_solver_proto_depIdxs = []int32{
+	2, // 0: aoc.v1.ListResponse.parts:type_name -> aoc.v1.Part
+	7, // 1: aoc.v1.SolveResponse.duration:type_name -> google.protobuf.Duration
+	6, // 2: aoc.v1.SolveEvent.progress:type_name -> aoc.v1.Progress
+	4, // 3: aoc.v1.SolveEvent.result:type_name -> aoc.v1.SolveResponse
+	0, // 4: aoc.v1.Solver.List:input_type -> aoc.v1.ListRequest
+	3, // 5: aoc.v1.Solver.Solve:input_type -> aoc.v1.SolveRequest
+	3, // 6: aoc.v1.Solver.SolveStream:input_type -> aoc.v1.SolveRequest
+	1, // 7: aoc.v1.Solver.List:output_type -> aoc.v1.ListResponse
+	4, // 8: aoc.v1.Solver.Solve:output_type -> aoc.v1.SolveResponse
+	5, // 9: aoc.v1.Solver.SolveStream:output_type -> aoc.v1.SolveEvent
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_aoc_v1_solver_proto_init() }
+func file_aoc_v1_solver_proto_init() {
+	if File_aoc_v1_solver_proto != nil {
+		return
+	}
+	file_aoc_v1_solver_proto_msgTypes[5].OneofWrappers = []any{
+		(*SolveEvent_Progress)(nil),
+		(*SolveEvent_Result)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aoc_v1_solver_proto_rawDesc), len(file_aoc_v1_solver_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aoc_v1_solver_proto_goTypes,
+		DependencyIndexes: file_aoc_v1_solver_proto_depIdxs,
+		MessageInfos:      file_aoc_v1_solver_proto_msgTypes,
+	}.Build()
+	File_aoc_v1_solver_proto = out.File
+	file_aoc_v1_solver_proto_goTypes = nil
+	file_aoc_v1_solver_proto_depIdxs = nil
+}
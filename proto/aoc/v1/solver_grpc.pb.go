@@ -0,0 +1,215 @@
+// Service contract for solving puzzles remotely, served by
+// `go run ./cmd serve -grpc-addr localhost:9090`. serve's HTTP routes mirror
+// it: GET /solvers is List, POST /solve/{day}/{part} is Solve, and
+// POST /solve/{day}/{part}/stream is SolveStream, one JSON SolveEvent per line.
+// The Go code beside this file is generated: run `buf generate` in proto/.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: aoc/v1/solver.proto
+
+package aocv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Solver_List_FullMethodName        = "/aoc.v1.Solver/List"
+	Solver_Solve_FullMethodName       = "/aoc.v1.Solver/Solve"
+	Solver_SolveStream_FullMethodName = "/aoc.v1.Solver/SolveStream"
+)
+
+// SolverClient is the client API for Solver service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SolverClient interface {
+	// List returns every registered day and part
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Solve runs one part on the given input
+	Solve(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (*SolveResponse, error)
+	// SolveStream is Solve with progress: parts registered with
+	// registry.RegisterProgress send Progress updates before the final Result
+	SolveStream(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SolveEvent], error)
+}
+
+type solverClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSolverClient(cc grpc.ClientConnInterface) SolverClient {
+	return &solverClient{cc}
+}
+
+func (c *solverClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, Solver_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solverClient) Solve(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (*SolveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SolveResponse)
+	err := c.cc.Invoke(ctx, Solver_Solve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solverClient) SolveStream(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SolveEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Solver_ServiceDesc.Streams[0], Solver_SolveStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SolveRequest, SolveEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Solver_SolveStreamClient = grpc.ServerStreamingClient[SolveEvent]
+
+// SolverServer is the server API for Solver service.
+// All implementations must embed UnimplementedSolverServer
+// for forward compatibility.
+type SolverServer interface {
+	// List returns every registered day and part
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Solve runs one part on the given input
+	Solve(context.Context, *SolveRequest) (*SolveResponse, error)
+	// SolveStream is Solve with progress: parts registered with
+	// registry.RegisterProgress send Progress updates before the final Result
+	SolveStream(*SolveRequest, grpc.ServerStreamingServer[SolveEvent]) error
+	mustEmbedUnimplementedSolverServer()
+}
+
+// UnimplementedSolverServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSolverServer struct{}
+
+func (UnimplementedSolverServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedSolverServer) Solve(context.Context, *SolveRequest) (*SolveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Solve not implemented")
+}
+func (UnimplementedSolverServer) SolveStream(*SolveRequest, grpc.ServerStreamingServer[SolveEvent]) error {
+	return status.Error(codes.Unimplemented, "method SolveStream not implemented")
+}
+func (UnimplementedSolverServer) mustEmbedUnimplementedSolverServer() {}
+func (UnimplementedSolverServer) testEmbeddedByValue()                {}
+
+// UnsafeSolverServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SolverServer will
+// result in compilation errors.
+type UnsafeSolverServer interface {
+	mustEmbedUnimplementedSolverServer()
+}
+
+func RegisterSolverServer(s grpc.ServiceRegistrar, srv SolverServer) {
+	// If the following call panics, it indicates UnimplementedSolverServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Solver_ServiceDesc, srv)
+}
+
+func _Solver_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolverServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Solver_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolverServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Solver_Solve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolverServer).Solve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Solver_Solve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolverServer).Solve(ctx, req.(*SolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Solver_SolveStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SolveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SolverServer).SolveStream(m, &grpc.GenericServerStream[SolveRequest, SolveEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Solver_SolveStreamServer = grpc.ServerStreamingServer[SolveEvent]
+
+// Solver_ServiceDesc is the grpc.ServiceDesc for Solver service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Solver_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aoc.v1.Solver",
+	HandlerType: (*SolverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _Solver_List_Handler,
+		},
+		{
+			MethodName: "Solve",
+			Handler:    _Solver_Solve_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SolveStream",
+			Handler:       _Solver_SolveStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "aoc/v1/solver.proto",
+}